@@ -1,3 +1,5 @@
+//go:generate protoc -I=./proto --go_out=plugins=grpc:./proto proto/alerter.proto
+
 package main
 
 import (
@@ -5,7 +7,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/brotherlogic/goserver"
@@ -16,8 +21,10 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	pb "github.com/brotherlogic/alerter/proto"
 	pbbs "github.com/brotherlogic/buildserver/proto"
 	pbd "github.com/brotherlogic/discovery/proto"
+	pbgh "github.com/brotherlogic/githubcard/proto"
 	pbgbs "github.com/brotherlogic/gobuildslave/proto"
 	pbg "github.com/brotherlogic/goserver/proto"
 )
@@ -55,24 +62,28 @@ func (p *prodGoserver) GetStats(ctx context.Context, ip string, port int32) (*pb
 // Discovery interface to discover
 type Discovery interface {
 	ListAllServices(ctx context.Context, req *pbd.ListRequest) (*pbd.ListResponse, error)
-	getFriends(ctx context.Context) (string, error)
+	getFriends(ctx context.Context) (string, string, error)
 	getRemoteFriends(ctx context.Context, addr string) (string, error)
 	list(ctx context.Context, addr string) ([]*pbd.RegistryEntry, error)
 }
 
-type prodDiscovery struct{}
+type prodDiscovery struct {
+	seeds func() []string
+}
 
-func (p *prodDiscovery) getFriends(ctx context.Context) (string, error) {
-	return p.getRemoteFriends(ctx, "192.168.86.49:50055")
+// getFriends asks each of the seeds in turn, returning the friends and the seed that answered
+func (p *prodDiscovery) getFriends(ctx context.Context) (string, string, error) {
+	return trySeeds(p.seeds(), func(seed string) (string, error) {
+		return p.getRemoteFriends(ctx, seed)
+	})
 }
 
 func (p *prodDiscovery) getRemoteFriends(ctx context.Context, addr string) (string, error) {
 	conn, err := grpc.Dial(addr, grpc.WithInsecure())
-	defer conn.Close()
-
 	if err != nil {
 		return "", err
 	}
+	defer conn.Close()
 
 	client := pbg.NewGoserverServiceClient(conn)
 	st, err := client.State(ctx, &pbg.Empty{})
@@ -160,60 +171,204 @@ func (p *prodGobuildSlave) ListJobs(ctx context.Context, server *pbd.RegistryEnt
 	return client.ListJobs(ctx, req)
 }
 
+// IssueTracker interface to githubcard
+type IssueTracker interface {
+	AddIssue(ctx context.Context, title, body string) (int32, error)
+	CloseIssue(ctx context.Context, number int32, note string) error
+}
+
+type prodIssueTracker struct {
+	dial func(server string) (*grpc.ClientConn, error)
+}
+
+func (p *prodIssueTracker) AddIssue(ctx context.Context, title, body string) (int32, error) {
+	conn, err := p.dial("githubcard")
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	client := pbgh.NewGithubClient(conn)
+	issue, err := client.AddIssue(ctx, &pbgh.Issue{Title: title, Body: body, Service: "alerter"})
+	if err != nil {
+		return 0, err
+	}
+	return issue.GetNumber(), nil
+}
+
+func (p *prodIssueTracker) CloseIssue(ctx context.Context, number int32, note string) error {
+	conn, err := p.dial("githubcard")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pbgh.NewGithubClient(conn)
+	_, err = client.DeleteIssue(ctx, &pbgh.DeleteRequest{Issue: &pbgh.Issue{Number: number, Service: "alerter", Body: note}})
+	return err
+}
+
 //Server main server type
 type Server struct {
 	*goserver.GoServer
 	buildServer      BuildServer
 	gobuildSlave     GobuildSlave
 	discover         Discovery
-	alertCount       int
 	goserver         Goserver
+	issues           IssueTracker
 	lastMismatchTime map[string]time.Time
 	highCPU          map[string]time.Time
+	alerts           *alertStore
+	config           *pb.Config
+	configMutex      *sync.RWMutex
+	configFile       string
+	seeds            []string
+	ruleBreaches     map[string]time.Time
+	buildSamples     []int64
+	serviceCounts    map[string]int32
+	jobStarts        map[string]int64
+	jobRestarts      map[string][]int64
+	jobStuck         map[string]time.Time
+	masterProblems   map[string]time.Time
+	mastered         map[string]bool
+	digest           []string
+	digestMutex      *sync.Mutex
+	silences         *pb.Silences
+	silencesMutex    *sync.Mutex
+	stateMutex       *sync.Mutex
+	master           bool
+	started          time.Time
+	taskLock         *sync.RWMutex
+	running          map[int64]context.CancelFunc
+	runningMutex     *sync.Mutex
+	runCount         int64
+	taskStatus       map[string]*taskStatus
+	statusMutex      *sync.Mutex
+	tasks            map[string]func(ctx context.Context) (time.Time, error)
 }
 
 // Init builds the server
 func Init() *Server {
 	s := &Server{
-		&goserver.GoServer{},
-		&prodBuildserver{},
-		&prodGobuildSlave{},
-		&prodDiscovery{},
-		0,
-		&prodGoserver{},
-		make(map[string]time.Time),
-		make(map[string]time.Time),
+		GoServer:         &goserver.GoServer{},
+		gobuildSlave:     &prodGobuildSlave{},
+		lastMismatchTime: make(map[string]time.Time),
+		highCPU:          make(map[string]time.Time),
+		config:           defaultConfig(),
+		configMutex:      &sync.RWMutex{},
+		started:          time.Now(),
+		ruleBreaches:     make(map[string]time.Time),
+		serviceCounts:    make(map[string]int32),
+		jobStarts:        make(map[string]int64),
+		jobRestarts:      make(map[string][]int64),
+		jobStuck:         make(map[string]time.Time),
+		masterProblems:   make(map[string]time.Time),
+		mastered:         make(map[string]bool),
+		digestMutex:      &sync.Mutex{},
+		silences:         &pb.Silences{},
+		silencesMutex:    &sync.Mutex{},
+		stateMutex:       &sync.Mutex{},
+		taskLock:         &sync.RWMutex{},
+		running:          make(map[int64]context.CancelFunc),
+		runningMutex:     &sync.Mutex{},
+		taskStatus:       make(map[string]*taskStatus),
+		statusMutex:      &sync.Mutex{},
 	}
+	s.alerts = newAlertStore(s.renotifyInterval, s.silenced, s.alertRaised, s.alertResolved)
+	s.discover = &prodDiscovery{seeds: s.discoverySeeds}
 	s.goserver = &prodGoserver{dial: s.DialMaster}
 	s.buildServer = &prodBuildserver{dial: s.DialMaster}
+	s.issues = &prodIssueTracker{dial: s.DialMaster}
+	s.tasks = map[string]func(ctx context.Context) (time.Time, error){
+		"run_version_check":     s.runVersionCheckLoop,
+		"look_for_go_version":   s.lookForGoVersion,
+		"check_friends":         s.checkFriends,
+		"evaluate_friends":      s.evaluateFriends,
+		"evaluate_rules":        s.evaluateRules,
+		"look_for_simul_builds": s.lookForSimulBuilds,
+		"send_digest":           s.sendDigest,
+		"look_for_missing":      s.lookForMissingServices,
+		"look_for_masters":      s.lookForMasters,
+		"look_for_zombies":      s.lookForZombies,
+		"look_for_collisions":   s.lookForCollisions,
+		"compare_apis":          s.compareAPIs,
+		"map_friends":           s.mapFriends,
+		"look_for_crash_loops":  s.lookForCrashLoops,
+	}
 	return s
 }
 
 // DoRegister does RPC registration
 func (s *Server) DoRegister(server *grpc.Server) {
-
-}
-
-// ReportHealth alerts if we're not healthy
-func (s *Server) ReportHealth() bool {
-	return true
+	pb.RegisterAlerterServiceServer(server, s)
 }
 
-// Shutdown the server
+// Shutdown the server, giving running tasks a chance to finish
 func (s *Server) Shutdown(ctx context.Context) error {
-	return nil
+	done := make(chan bool)
+	go func() {
+		s.taskLock.Lock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.cancelTasks()
+	case <-time.After(shutdownDeadline):
+		s.cancelTasks()
+	}
+
+	// Let the cancelled tasks finish their writes before we save
+	select {
+	case <-done:
+	case <-time.After(cancelDeadline):
+		s.Log("Saving state with tasks still running")
+	}
+
+	if !s.isMaster() {
+		return nil
+	}
+
+	sctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return s.saveState(sctx)
 }
 
 // Mote promotes/demotes this server
 func (s *Server) Mote(ctx context.Context, master bool) error {
-	return nil
+	if master {
+		return s.promote(ctx)
+	}
+	return s.demote(ctx)
 }
 
 // GetState gets the state of the server
 func (s *Server) GetState() []*pbg.State {
-	return []*pbg.State{
-		&pbg.State{Key: "blah", Value: int64(100)},
+	peak, average := s.buildSampleStats()
+	raised := s.alerts.raisedCounts()
+	total := int64(0)
+	for _, count := range raised {
+		total += count
+	}
+	states := []*pbg.State{
+		&pbg.State{Key: "alert_count", Value: total},
+		&pbg.State{Key: "open_alerts", Value: s.alerts.openCount()},
+		&pbg.State{Key: "concurrent_builds_peak", Value: peak},
+		&pbg.State{Key: "concurrent_builds_average", Fraction: average},
+		&pbg.State{Key: "suppressed_repeats", Value: s.alerts.suppressedCount()},
+	}
+
+	checks := []string{}
+	for check := range raised {
+		checks = append(checks, check)
 	}
+	sort.Strings(checks)
+	for _, check := range checks {
+		states = append(states, &pbg.State{Key: "raised_" + check, Value: raised[check]})
+	}
+
+	return append(states, s.taskStates()...)
 }
 
 func (s *Server) runVersionCheckLoop(ctx context.Context) (time.Time, error) {
@@ -223,6 +378,8 @@ func (s *Server) runVersionCheckLoop(ctx context.Context) (time.Time, error) {
 
 func main() {
 	var quiet = flag.Bool("quiet", false, "Show all output")
+	var config = flag.String("config", "", "Text proto config file to use in place of the keystore config")
+	var seeds = flag.String("discovery_seeds", "", "Comma separated discovery addresses to find friends through, tried in order")
 	flag.Parse()
 
 	//Turn off logging
@@ -231,6 +388,10 @@ func main() {
 		log.SetOutput(ioutil.Discard)
 	}
 	server := Init()
+	server.configFile = *config
+	if len(*seeds) > 0 {
+		server.seeds = strings.Split(*seeds, ",")
+	}
 	server.GoServer.KSclient = *keystoreclient.GetClient(server.DialMaster)
 	server.PrepServer()
 	server.Register = server
@@ -240,10 +401,13 @@ func main() {
 		return
 	}
 
-	server.RegisterLockingTask(server.runVersionCheckLoop, "run_version_check")
-	server.RegisterLockingTask(server.lookForGoVersion, "look_for_go_version")
-	server.RegisterLockingTask(server.checkFriends, "check_friends")
-	server.RegisterLockingTask(server.evaluateFriends, "evaluate_friends")
+	for name := range server.tasks {
+		server.RegisterLockingTask(server.lockingTask(name), name)
+	}
+
+	go server.refreshConfig(context.Background())
+	go server.watchdog(context.Background())
+	go server.refreshSilences(context.Background())
 
 	server.Serve()
 }
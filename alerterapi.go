@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+// ListAlerts lists the active alerts held by the alerter
+func (s *Server) ListAlerts(ctx context.Context, req *pb.ListAlertsRequest) (*pb.ListAlertsResponse, error) {
+	return &pb.ListAlertsResponse{Alerts: s.alerts.list(req.GetIncludeResolved())}, nil
+}
+
+// GetAlert gets a single alert along with its history
+func (s *Server) GetAlert(ctx context.Context, req *pb.GetAlertRequest) (*pb.GetAlertResponse, error) {
+	alert, ok := s.alerts.get(req.GetKey())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Unable to locate alert %v", req.GetKey())
+	}
+
+	return &pb.GetAlertResponse{Alert: alert}, nil
+}
+
+// AcknowledgeAlert marks a firing alert as acknowledged
+func (s *Server) AcknowledgeAlert(ctx context.Context, req *pb.AcknowledgeAlertRequest) (*pb.AcknowledgeAlertResponse, error) {
+	alert, err := s.alerts.acknowledge(req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AcknowledgeAlertResponse{Alert: alert}, nil
+}
+
+// RunTask runs one of the locking tasks on demand. Only the master holds the alert state, so
+// any other alerter refuses the run. The run does not take the task's distributed lock, so it
+// can overlap a scheduled run of the same task on another alerter.
+func (s *Server) RunTask(ctx context.Context, req *pb.RunTaskRequest) (*pb.RunTaskResponse, error) {
+	next, err := s.runTask(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RunTaskResponse{NextRunTime: next.Unix()}, nil
+}
+
+// AddSilence adds a silence, holding back any alerts it matches until it ends
+func (s *Server) AddSilence(ctx context.Context, req *pb.AddSilenceRequest) (*pb.AddSilenceResponse, error) {
+	silence := req.GetSilence()
+	if silence == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "No silence was supplied")
+	}
+	if silence.GetStart() == 0 {
+		silence.Start = time.Now().Unix()
+	}
+	if err := validateSilence(silence); err != nil {
+		return nil, err
+	}
+	silence.Id = fmt.Sprintf("%v", time.Now().UnixNano())
+
+	err := s.updateSilences(ctx, func(silences *pb.Silences) error {
+		silences.Silences = append(silences.Silences, silence)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AddSilenceResponse{Silence: silence}, nil
+}
+
+// ListSilences lists the silences we hold, including those that have ended
+func (s *Server) ListSilences(ctx context.Context, req *pb.ListSilencesRequest) (*pb.ListSilencesResponse, error) {
+	s.silencesMutex.Lock()
+	defer s.silencesMutex.Unlock()
+	return &pb.ListSilencesResponse{Silences: s.silences.GetSilences()}, nil
+}
+
+// DeleteSilence removes a silence
+func (s *Server) DeleteSilence(ctx context.Context, req *pb.DeleteSilenceRequest) (*pb.DeleteSilenceResponse, error) {
+	err := s.updateSilences(ctx, func(silences *pb.Silences) error {
+		for i, silence := range silences.GetSilences() {
+			if silence.GetId() == req.GetId() {
+				silences.Silences = append(silences.Silences[:i], silences.Silences[i+1:]...)
+				return nil
+			}
+		}
+		return status.Errorf(codes.NotFound, "Unable to locate silence %v", req.GetId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteSilenceResponse{}, nil
+}
+
+// GetFriendGraph walks the discovery friends, returning the graph and a rendering of it
+func (s *Server) GetFriendGraph(ctx context.Context, req *pb.GetFriendGraphRequest) (*pb.GetFriendGraphResponse, error) {
+	graph, err := s.buildFriendGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := renderGraph(graph, req.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetFriendGraphResponse{Graph: graph, Rendered: rendered}, nil
+}
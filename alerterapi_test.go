@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+func TestListAlerts(t *testing.T) {
+	s := InitTestServer()
+	s.lookForGoVersion(context.Background())
+
+	alerts, err := s.ListAlerts(context.Background(), &pb.ListAlertsRequest{})
+	if err != nil {
+		t.Fatalf("Unable to list alerts: %v", err)
+	}
+
+	if len(alerts.GetAlerts()) != 1 || len(alerts.GetAlerts()[0].GetHistory()) != 0 {
+		t.Errorf("Bad alert listing: %v", alerts)
+	}
+}
+
+func TestGetAlert(t *testing.T) {
+	s := InitTestServer()
+	s.lookForGoVersion(context.Background())
+	s.lookForGoVersion(context.Background())
+
+	alert, err := s.GetAlert(context.Background(), &pb.GetAlertRequest{Key: "look_for_go_version:gobuildslave"})
+	if err != nil {
+		t.Fatalf("Unable to get alert: %v", err)
+	}
+
+	if alert.GetAlert().GetCount() != 2 || len(alert.GetAlert().GetHistory()) != 2 {
+		t.Errorf("Bad alert: %v", alert)
+	}
+}
+
+func TestGetAlertMissing(t *testing.T) {
+	s := InitTestServer()
+
+	alert, err := s.GetAlert(context.Background(), &pb.GetAlertRequest{Key: "look_for_go_version:gobuildslave"})
+	if err == nil {
+		t.Errorf("Should have failed: %v", alert)
+	}
+}
+
+func TestAcknowledgeAlert(t *testing.T) {
+	s := InitTestServer()
+	s.lookForGoVersion(context.Background())
+
+	alert, err := s.AcknowledgeAlert(context.Background(), &pb.AcknowledgeAlertRequest{Key: "look_for_go_version:gobuildslave"})
+	if err != nil {
+		t.Fatalf("Unable to acknowledge alert: %v", err)
+	}
+
+	if alert.GetAlert().GetState() != pb.Alert_ACKNOWLEDGED {
+		t.Errorf("Alert was not acknowledged: %v", alert)
+	}
+}
+
+func TestAcknowledgeAlertMissing(t *testing.T) {
+	s := InitTestServer()
+
+	alert, err := s.AcknowledgeAlert(context.Background(), &pb.AcknowledgeAlertRequest{Key: "look_for_go_version:gobuildslave"})
+	if err == nil {
+		t.Errorf("Should have failed: %v", alert)
+	}
+}
+
+func TestRunTask(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+
+	_, err := s.RunTask(context.Background(), &pb.RunTaskRequest{Name: "check_friends"})
+	if err != nil {
+		t.Errorf("Unable to run task: %v", err)
+	}
+}
+
+func TestRunTaskAwayFromMaster(t *testing.T) {
+	s := InitTestServer()
+
+	_, err := s.RunTask(context.Background(), &pb.RunTaskRequest{Name: "check_friends"})
+	if status.Convert(err).Code() != codes.FailedPrecondition {
+		t.Errorf("Task ran away from the master: %v", err)
+	}
+}
+
+func TestRunTaskMissing(t *testing.T) {
+	s := InitTestServer()
+
+	_, err := s.RunTask(context.Background(), &pb.RunTaskRequest{Name: "madeup"})
+	if err == nil {
+		t.Errorf("Should have failed")
+	}
+}
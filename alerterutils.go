@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
 	"time"
 
@@ -10,61 +9,103 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	pb "github.com/brotherlogic/alerter/proto"
 	pbbs "github.com/brotherlogic/buildserver/proto"
 	pbd "github.com/brotherlogic/discovery/proto"
 	pbgs "github.com/brotherlogic/gobuildslave/proto"
-	"github.com/golang/protobuf/proto"
 )
 
+// alertRaised routes a firing alert to its notifiers, the digest or the logs
+func (s *Server) alertRaised(ctx context.Context, alert *pb.Alert) {
+	route := s.route(alert)
+	switch route.GetAction() {
+	case pb.Route_NOTIFY:
+		s.notify(ctx, alert, route.GetNotifiers())
+	case pb.Route_DIGEST:
+		s.addToDigest(alert)
+	case pb.Route_LOG:
+		s.Log(fmt.Sprintf("[%v] %v: %v", severity(alert), alert.GetTitle(), alert.GetBody()))
+	}
+}
+
+// alertResolved tells the notifiers that heard about an alert that it has cleared. Any issue
+// opened for the alert is closed, even if the alert is no longer routed to issues.
+func (s *Server) alertResolved(ctx context.Context, alert *pb.Alert) {
+	route := s.route(alert)
+	notifiers := []string{}
+	if route.GetAction() == pb.Route_NOTIFY {
+		notifiers = route.GetNotifiers()
+		if len(notifiers) == 0 {
+			notifiers = []string{"issue"}
+		}
+	}
+	if alert.GetIssueNumber() != 0 && !s.hasIssueNotifier(notifiers) {
+		notifiers = append(notifiers, "issue")
+	}
+
+	if len(notifiers) > 0 {
+		s.notify(ctx, alert, notifiers)
+	}
+	s.Log(fmt.Sprintf("Resolved %v after %v", alert.GetKey(), time.Unix(alert.GetResolvedTime(), 0).Sub(time.Unix(alert.GetFirstRaised(), 0))))
+}
+
 func (s *Server) evaluateFriends(ctx context.Context) (time.Time, error) {
-	friends, err := s.discover.getFriends(ctx)
+	friends, seed, err := s.discover.getFriends(ctx)
 	if err != nil {
 		if status.Convert(err).Code() != codes.FailedPrecondition {
-			s.RaiseIssue(ctx, "Friend Evaluator", fmt.Sprintf("Unable to evalute friends: %v", err), false)
+			s.alerts.fire(ctx, &pb.Alert{Check: "evaluate_friends", Labels: map[string]string{"service": "discovery"}, Severity: pb.Severity_WARNING, Title: "Friend Evaluator", Body: fmt.Sprintf("Unable to evalute friends: %v", err)})
 		}
 		return time.Now().Add(time.Minute * 5), err
 
 	}
 
-	strFriends := strings.Split(friends, " ")
-	rand.Shuffle(len(strFriends), func(i, j int) { strFriends[i], strFriends[j] = strFriends[j], strFriends[i] })
-
+	strFriends := parseFriends(friends)
 	if len(strFriends) < 2 {
-		s.RaiseIssue(ctx, "Friend Evaluator", fmt.Sprintf("Unable to evaluate friends - we have less than 2: %v", strFriends), false)
+		s.alerts.fire(ctx, &pb.Alert{Check: "evaluate_friends", Labels: map[string]string{"service": "discovery", "seed": seed}, Severity: pb.Severity_WARNING, Title: "Friend Evaluator", Body: fmt.Sprintf("Unable to evaluate friends - we have less than 2: %v", strFriends)})
 		return time.Now().Add(time.Minute * 5), fmt.Errorf("Short friends")
 	}
 
-	friend1 := strFriends[0]
-	friend2 := strFriends[1]
-	list1, err1 := s.discover.list(ctx, friend1)
-	list2, err2 := s.discover.list(ctx, friend2)
-	if err1 != nil || err2 != nil {
-		s.RaiseIssue(ctx, "Friend Evaluator", fmt.Sprintf("%v or %v is causing an issue", err1, err2), false)
-		return time.Now().Add(time.Minute * 5), err1
+	listings := make(map[string][]*pbd.RegistryEntry)
+	failures := []string{}
+	for _, friend := range strFriends {
+		list, err := s.discover.list(ctx, friend)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%v: %v", friend, err))
+		} else {
+			listings[friend] = list
+		}
 	}
 
-	for _, entry1 := range list1 {
-		found := false
-		for _, entry2 := range list2 {
-			if proto.Equal(entry1, entry2) {
-				found = true
-			}
-		}
+	if len(failures) > 0 {
+		s.alerts.fire(ctx, &pb.Alert{Check: "evaluate_friends", Labels: map[string]string{"service": "discovery", "seed": seed}, Severity: pb.Severity_WARNING, Title: "Friend Evaluator", Body: fmt.Sprintf("Unable to list from %v", failures)})
+	}
+	if len(listings) < 2 {
+		return time.Now().Add(time.Minute * 5), fmt.Errorf("Unable to get enough listings: %v", failures)
+	}
 
-		if !found {
-			s.RaiseIssue(ctx, "Friend Evaluator", fmt.Sprintf("Mismatch in directory listing %v and then %v (%v)", list1, list2, entry1), false)
-			return time.Now().Add(time.Minute * 5), fmt.Errorf("Mismatch")
-		}
+	s.recordExamined(ctx, len(listings), 0)
+	diffs := diffListings(listings)
+	seen := make(map[string]bool)
+	if len(failures) > 0 {
+		seen[""] = true
+	}
+	for _, diff := range diffs {
+		seen[diff.key] = true
+		s.alerts.fire(ctx, &pb.Alert{Check: "evaluate_friends", Subject: diff.key, Labels: map[string]string{"service": "discovery", "seed": seed, "entry": diff.key}, Severity: pb.Severity_CRITICAL, Title: "Friend Evaluator", Body: fmt.Sprintf("Mismatch in directory listing: %v", diff)})
 	}
+	s.alerts.passUnseen(ctx, "evaluate_friends", seen)
 
+	if len(diffs) > 0 {
+		return time.Now().Add(time.Minute * 5), fmt.Errorf("Mismatch on %v entries", len(diffs))
+	}
 	return time.Now().Add(time.Minute * 5), nil
 }
 
 func (s *Server) checkFriends(ctx context.Context) (time.Time, error) {
-	friends, err := s.discover.getFriends(ctx)
+	friends, seed, err := s.discover.getFriends(ctx)
 	if err != nil {
 		if status.Convert(err).Code() != codes.FailedPrecondition {
-			s.RaiseIssue(ctx, "Friend Finder", fmt.Sprintf("Unable to find friends: %v", err), false)
+			s.alerts.fire(ctx, &pb.Alert{Check: "check_friends", Labels: map[string]string{"service": "discovery"}, Severity: pb.Severity_WARNING, Title: "Friend Finder", Body: fmt.Sprintf("Unable to find friends: %v", err)})
 		}
 		return time.Now().Add(time.Minute * 5), err
 	}
@@ -72,14 +113,17 @@ func (s *Server) checkFriends(ctx context.Context) (time.Time, error) {
 	for _, friend := range strings.Split(friends, " ") {
 		rfriends, err := s.discover.getRemoteFriends(ctx, strings.Replace(strings.Replace(friend, "[", "", -1), "]", "", -1))
 		if err != nil {
-			s.RaiseIssue(ctx, "Friend Finder", fmt.Sprintf("Unable to get remote friends: %v", err), false)
+			s.alerts.fire(ctx, &pb.Alert{Check: "check_friends", Labels: map[string]string{"service": "discovery", "seed": seed}, Severity: pb.Severity_WARNING, Title: "Friend Finder", Body: fmt.Sprintf("Unable to get remote friends: %v", err)})
 			return time.Now().Add(time.Minute * 5), err
 		}
 		if len(strings.Split(rfriends, " ")) != len(strings.Split(friends, " ")) {
-			s.RaiseIssue(ctx, "Friend mismatch", fmt.Sprintf("For %v,%v -> %v != %v", s.Registry.Ip, friend, friends, rfriends), false)
+			s.alerts.fire(ctx, &pb.Alert{Check: "check_friends", Subject: friend, Labels: map[string]string{"service": "discovery", "seed": seed, "identifier": friend}, Severity: pb.Severity_WARNING, Title: "Friend mismatch", Body: fmt.Sprintf("For %v,%v -> %v != %v", s.Registry.Ip, friend, friends, rfriends)})
+		} else {
+			s.alerts.pass(ctx, "check_friends", friend)
 		}
 	}
 
+	s.alerts.pass(ctx, "check_friends", "")
 	return time.Now().Add(time.Minute * 5), nil
 }
 
@@ -87,79 +131,165 @@ func (s *Server) runVersionCheck(ctx context.Context, delay time.Duration) (time
 	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
 	if err == nil {
 		if err == nil {
+			seen := make(map[string]bool)
 			for _, service := range serv.Services.Services {
 				if service.Name == "gobuildslave" {
 					jobs, err := s.gobuildSlave.ListJobs(ctx, service, &pbgs.ListRequest{})
 					if err == nil {
+						s.recordExamined(ctx, 1, len(jobs.Jobs))
 						for _, job := range jobs.Jobs {
+							seen[service.Identifier+job.Job.Name] = true
 							runningVersion := job.RunningVersion
 							versions, err := s.buildServer.GetVersions(ctx, &pbbs.VersionRequest{JustLatest: true, Job: job.Job})
 							if err == nil && len(versions.GetVersions()) == 0 {
-								s.RaiseIssue(ctx, "Version Problem", fmt.Sprintf("%v has no version built", job.Job.Name), false)
+								s.alerts.fire(ctx, &pb.Alert{Check: "run_version_check", Subject: service.Identifier + job.Job.Name, Labels: map[string]string{"service": job.Job.Name, "identifier": service.Identifier}, Severity: pb.Severity_WARNING, Title: "Version Problem", Body: fmt.Sprintf("%v has no version built", job.Job.Name)})
 								return time.Now().Add(time.Minute * 5), nil
 							}
 							if len(versions.GetVersions()) > 0 {
+								s.alerts.pass(ctx, "run_version_check", service.Identifier+job.Job.Name)
 								compiledVersion := versions.GetVersions()[0].GetVersion()
 								if compiledVersion != runningVersion && len(runningVersion) > 0 {
-									if _, ok := s.lastMismatchTime[service.Identifier+job.Job.Name]; !ok {
-										s.lastMismatchTime[service.Identifier+job.Job.Name] = time.Now()
+									since := s.mismatchSince(service.Identifier + job.Job.Name)
+									if time.Since(since) > s.versionGracePeriod(job.Job.Name, delay) {
+										s.alerts.fire(ctx, &pb.Alert{Check: "stale_version", Subject: service.Identifier + job.Job.Name, Labels: map[string]string{"service": job.Job.Name, "identifier": service.Identifier}, Severity: pb.Severity_WARNING, Title: "Stale Version",
+											Body: fmt.Sprintf("%v on %v is running %v but %v has been built (drifting for %v, since %v)", job.Job.Name, service.Identifier, runningVersion, compiledVersion, driftBand(time.Since(since)), since.Format(time.RFC822))})
 									}
-
 								} else {
-									delete(s.lastMismatchTime, service.Identifier+job.Job.Name)
+									s.clearMismatch(service.Identifier + job.Job.Name)
+									s.alerts.pass(ctx, "stale_version", service.Identifier+job.Job.Name)
 								}
 							}
 						}
 					}
 				}
 			}
+			s.alerts.passUnseen(ctx, "run_version_check", seen)
+			s.alerts.passUnseen(ctx, "stale_version", seen)
+			s.pruneMismatches(seen)
 		}
 	}
 
 	return time.Now().Add(time.Minute * 5), err
 }
 
-func (s *Server) lookForSimulBuilds(ctx context.Context) error {
+// driftBand describes how long a drift has lasted coarsely enough that the description
+// only changes a handful of times, each of which is worth notifying about
+func driftBand(drift time.Duration) string {
+	bands := []struct {
+		drift       time.Duration
+		description string
+	}{
+		{time.Hour * 24 * 30, "over a month"},
+		{time.Hour * 24 * 7, "over a week"},
+		{time.Hour * 24, "over a day"},
+		{time.Hour * 6, "over 6 hours"},
+		{time.Hour, "over an hour"},
+	}
+
+	for _, band := range bands {
+		if drift >= band.drift {
+			return band.description
+		}
+	}
+	return "under an hour"
+}
+
+func (s *Server) lookForSimulBuilds(ctx context.Context) (time.Time, error) {
 	s.Log("Looking for concurrent builds")
 	stats, err := s.goserver.GetStatsSingle(ctx, "buildserver")
-	if err == nil {
-		for _, state := range stats.States {
-			if state.Key == "concurrent_builds" && state.Value > int64(4) {
-				s.alertCount++
-				s.RaiseIssue(ctx, "ConcurrentBuilds", fmt.Sprintf("Buildserver is reporting concurrent builds: %v", state.Value), false)
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+
+	for _, state := range stats.States {
+		if state.Key == "concurrent_builds" {
+			window := int(s.getConfig().GetConcurrentBuildsWindow())
+			if window < 1 {
+				window = 1
+			}
+			samples := s.addBuildSample(state.Value, window)
+
+			// Only alert once the whole window is overloaded
+			overloaded := len(samples) == window
+			for _, sample := range samples {
+				if sample <= s.getConfig().GetConcurrentBuildsThreshold() {
+					overloaded = false
+				}
+			}
+
+			if overloaded {
+				s.alerts.fire(ctx, &pb.Alert{Check: "look_for_simul_builds", Subject: "buildserver", Labels: map[string]string{"service": "buildserver"}, Severity: pb.Severity_WARNING, Title: "ConcurrentBuilds", Body: fmt.Sprintf("Buildserver has reported more than %v concurrent builds for the last %v samples", s.getConfig().GetConcurrentBuildsThreshold(), window)})
+			} else {
+				s.alerts.pass(ctx, "look_for_simul_builds", "buildserver")
 			}
 		}
 	}
-	return nil
+
+	return time.Now().Add(time.Minute * 5), nil
+}
+
+// buildSampleStats gets the peak and average of the concurrent build samples
+func (s *Server) buildSampleStats() (int64, float64) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+
+	peak := int64(0)
+	sum := int64(0)
+	for _, sample := range s.buildSamples {
+		if sample > peak {
+			peak = sample
+		}
+		sum += sample
+	}
+
+	if len(s.buildSamples) == 0 {
+		return 0, 0
+	}
+	return peak, float64(sum) / float64(len(s.buildSamples))
 }
 
 func (s *Server) lookForGoVersion(ctx context.Context) (time.Time, error) {
-	s.Log("Looking for high CPU usage")
+	s.Log("Looking for bad go versions")
+
+	buildserverVersion := ""
+	if s.getConfig().GetGoPolicy().GetMatchBuildserver() {
+		stats, err := s.goserver.GetStatsSingle(ctx, "buildserver")
+		if err != nil {
+			return time.Now().Add(time.Minute * 5), err
+		}
+		for _, state := range stats.States {
+			if state.Key == "go_version" {
+				buildserverVersion = state.Text
+			}
+		}
+	}
 
 	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
 	if err == nil {
+		answered := make(map[string]bool)
 		for _, service := range serv.Services.Services {
-			if service.Name == "gobuildslave" {
-				stats, err := s.goserver.GetStats(ctx, service.Ip, service.Port)
-
-				if err == nil {
-					seen := false
-					for _, state := range stats.States {
-						if state.Key == "go_version" && state.Text != "go1.11.6" {
-							s.alertCount++
-							s.RaiseIssue(ctx, "Bad Version", fmt.Sprintf("%v (%v) is on the wrong go version", service.Identifier, state.Text), false)
-						}
-						if state.Key == "go_version" {
-							seen = true
+			stats, err := s.goserver.GetStats(ctx, service.Ip, service.Port)
+
+			if err == nil {
+				s.recordExamined(ctx, 1, 0)
+				answered[service.Identifier+service.Name] = true
+				seen := false
+				for _, state := range stats.States {
+					if state.Key == "go_version" {
+						seen = true
+						if err := s.checkGoVersion(state.Text, buildserverVersion); err != nil {
+							s.alerts.fire(ctx, &pb.Alert{Check: "look_for_go_version", Subject: service.Identifier + service.Name, Labels: labels(service), Severity: pb.Severity_WARNING, Title: "Bad Version", Body: fmt.Sprintf("%v on %v is on the wrong go version: %v", service.Name, service.Identifier, err)})
+						} else {
+							s.alerts.pass(ctx, "look_for_go_version", service.Identifier+service.Name)
 						}
 					}
-					if !seen {
-						s.alertCount++
-						s.RaiseIssue(ctx, "No Version", fmt.Sprintf("%v is not reporting a go version", service.Identifier), false)
-					}
+				}
+				if !seen {
+					s.alerts.fire(ctx, &pb.Alert{Check: "look_for_go_version", Subject: service.Identifier + service.Name, Labels: labels(service), Severity: pb.Severity_INFO, Title: "No Version", Body: fmt.Sprintf("%v on %v is not reporting a go version", service.Name, service.Identifier)})
 				}
 			}
 		}
+		s.alerts.passUnseen(ctx, "look_for_go_version", answered)
 	}
 
 	return time.Now().Add(time.Minute * 5), nil
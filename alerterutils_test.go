@@ -2,13 +2,17 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	pbg "github.com/brotherlogic/goserver/proto"
 	"github.com/brotherlogic/keystore/client"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	pb "github.com/brotherlogic/alerter/proto"
 	pbbs "github.com/brotherlogic/buildserver/proto"
 	pbd "github.com/brotherlogic/discovery/proto"
 	pbgbs "github.com/brotherlogic/gobuildslave/proto"
@@ -20,9 +24,15 @@ type testDiscovery struct {
 	friends    string
 	faillist   bool
 	diff       bool
+	services   []*pbd.RegistryEntry
+	remote     map[string]string
+	seed       string
 }
 
 func (t *testDiscovery) ListAllServices(ctx context.Context, req *pbd.ListRequest) (*pbd.ListResponse, error) {
+	if t.services != nil {
+		return &pbd.ListResponse{Services: &pbd.ServiceList{Services: t.services}}, nil
+	}
 	return &pbd.ListResponse{Services: &pbd.ServiceList{Services: []*pbd.RegistryEntry{&pbd.RegistryEntry{Name: "gobuildslave", Ip: "1234", Port: int32(123)}}}}, nil
 }
 
@@ -39,20 +49,30 @@ func (t *testDiscovery) list(ctx context.Context, addr string) ([]*pbd.RegistryE
 	return []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "one"}, &pbd.RegistryEntry{Identifier: "two"}}, nil
 }
 
-func (t *testDiscovery) getFriends(ctx context.Context) (string, error) {
+func (t *testDiscovery) getFriends(ctx context.Context) (string, string, error) {
 	if t.failget && !t.failremote {
-		return "", fmt.Errorf("Built to fail")
+		return "", "", fmt.Errorf("Built to fail")
+	}
+	seed := "seed"
+	if len(t.seed) > 0 {
+		seed = t.seed
 	}
 	if len(t.friends) > 0 {
-		return t.friends, nil
+		return t.friends, seed, nil
 	}
-	return "yeps deps", nil
+	return "yeps deps", seed, nil
 }
 
 func (t *testDiscovery) getRemoteFriends(ctx context.Context, addr string) (string, error) {
 	if t.failremote && !t.failget {
 		return "", fmt.Errorf("Built to fail")
 	}
+	if t.remote != nil {
+		if friends, ok := t.remote[addr]; ok {
+			return friends, nil
+		}
+		return "", fmt.Errorf("Built to fail")
+	}
 	return "yep", nil
 }
 
@@ -73,10 +93,14 @@ func (t *testBuildserver) GetVersions(ctx context.Context, req *pbbs.VersionRequ
 }
 
 type testGobuildslave struct {
-	job bool
+	job  bool
+	jobs []*pbgbs.JobAssignment
 }
 
 func (t *testGobuildslave) ListJobs(ctx context.Context, server *pbd.RegistryEntry, req *pbgbs.ListRequest) (*pbgbs.ListResponse, error) {
+	if t.jobs != nil {
+		return &pbgbs.ListResponse{Jobs: t.jobs}, nil
+	}
 	if !t.job {
 		return &pbgbs.ListResponse{Jobs: []*pbgbs.JobAssignment{&pbgbs.JobAssignment{RunningVersion: "not_testing", Job: &pbgbs.Job{Name: "madeup"}}}}, nil
 	}
@@ -87,9 +111,16 @@ type testGoserver struct {
 	reportsNormal    bool
 	goversion        bool
 	concurrentBuilds int64
+	stats            map[string]*pbg.ServerState
 }
 
 func (t *testGoserver) GetStats(ctx context.Context, server string, port int32) (*pbg.ServerState, error) {
+	if t.stats != nil {
+		if stats, ok := t.stats[fmt.Sprintf("%v:%v", server, port)]; ok {
+			return stats, nil
+		}
+		return nil, status.Errorf(codes.Unavailable, "Built to fail")
+	}
 	if t.reportsNormal {
 		if t.goversion {
 			return &pbg.ServerState{States: []*pbg.State{&pbg.State{Key: "go_version", Text: "go1.11.6"}, &pbg.State{Key: "concurrent_builds", Value: int64(2)}, &pbg.State{Key: "cpu", Fraction: float64(200)}}}, nil
@@ -109,6 +140,29 @@ func (t *testGoserver) GetStatsSingle(ctx context.Context, server string) (*pbg.
 	return &pbg.ServerState{States: []*pbg.State{&pbg.State{Key: "concurrent_builds", Value: t.concurrentBuilds}, &pbg.State{Key: "cpu", Fraction: float64(50)}}}, nil
 }
 
+type testIssueTracker struct {
+	opened map[int32]string
+	closed map[int32]string
+	fail   bool
+}
+
+func (t *testIssueTracker) AddIssue(ctx context.Context, title, body string) (int32, error) {
+	if t.fail {
+		return 0, fmt.Errorf("Built to fail")
+	}
+	number := int32(len(t.opened) + 1)
+	t.opened[number] = title
+	return number, nil
+}
+
+func (t *testIssueTracker) CloseIssue(ctx context.Context, number int32, note string) error {
+	if t.fail {
+		return fmt.Errorf("Built to fail")
+	}
+	t.closed[number] = note
+	return nil
+}
+
 func InitTestServer() *Server {
 	s := Init()
 	s.discover = &testDiscovery{}
@@ -118,15 +172,25 @@ func InitTestServer() *Server {
 	s.SkipIssue = true
 	s.GoServer.KSclient = *keystoreclient.GetTestClient(".test")
 	s.goserver = &testGoserver{}
+	s.issues = &testIssueTracker{opened: make(map[int32]string), closed: make(map[int32]string)}
 	s.Registry = &pbd.RegistryEntry{}
 	return s
 }
 
+// alertCount is the number of notifications the checks have sent
+func alertCount(s *Server) int64 {
+	total := int64(0)
+	for _, count := range s.alerts.raisedCounts() {
+		total += count
+	}
+	return total
+}
+
 func TestAlert(t *testing.T) {
 	s := InitTestServer()
 	s.runVersionCheck(context.Background(), time.Hour)
 
-	if s.alertCount != 0 {
+	if alertCount(s) != 0 {
 		t.Errorf("Error in alerting")
 	}
 }
@@ -136,7 +200,7 @@ func TestAlertJobDiff(t *testing.T) {
 	s.gobuildSlave = &testGobuildslave{job: true}
 	s.runVersionCheck(context.Background(), time.Hour)
 
-	if s.alertCount != 0 {
+	if alertCount(s) != 0 {
 		t.Errorf("Error in alerting")
 	}
 }
@@ -146,8 +210,8 @@ func TestAlertEmptyBuild(t *testing.T) {
 	s.buildServer = &testBuildserver{none: true}
 	s.runVersionCheck(context.Background(), time.Hour)
 
-	if s.alertCount != 0 {
-		t.Errorf("Error in alerting")
+	if alert, ok := s.alerts.get("run_version_check:madeup"); !ok || alertCount(s) != 1 {
+		t.Errorf("Missing build was not raised once: %v", alert)
 	}
 }
 
@@ -157,7 +221,7 @@ func TestAlertClear(t *testing.T) {
 	s.buildServer = &testBuildserver{match: true}
 	s.runVersionCheck(context.Background(), time.Hour)
 
-	if s.alertCount != 0 {
+	if alertCount(s) != 0 {
 		t.Errorf("Error in alerting")
 	}
 }
@@ -166,8 +230,8 @@ func TestBuildAlert(t *testing.T) {
 	s := InitTestServer()
 	s.lookForSimulBuilds(context.Background())
 
-	if s.alertCount != 0 {
-		t.Errorf("Error in alerting: %v", s.alertCount)
+	if alertCount(s) != 0 {
+		t.Errorf("Error in alerting: %v", alertCount(s))
 	}
 }
 
@@ -175,17 +239,51 @@ func TestBuildAlertFires(t *testing.T) {
 	s := InitTestServer()
 	s.goserver = &testGoserver{concurrentBuilds: 5}
 	s.lookForSimulBuilds(context.Background())
+	s.lookForSimulBuilds(context.Background())
+	s.lookForSimulBuilds(context.Background())
 
-	if s.alertCount == 0 {
-		t.Errorf("Error in alerting: %v", s.alertCount)
+	if alertCount(s) == 0 {
+		t.Errorf("Error in alerting: %v", alertCount(s))
+	}
+}
+
+func TestBuildAlertStableUnderLoad(t *testing.T) {
+	s := InitTestServer()
+	for i := int64(5); i < 10; i++ {
+		s.goserver = &testGoserver{concurrentBuilds: i}
+		s.lookForSimulBuilds(context.Background())
+	}
+
+	alert, ok := s.alerts.get("look_for_simul_builds:buildserver")
+	if !ok || alert.GetSuppressed() != 2 {
+		t.Errorf("Changing load notified again: %v", alert)
+	}
+}
+
+func TestBuildAlertIgnoresSpike(t *testing.T) {
+	s := InitTestServer()
+	s.goserver = &testGoserver{concurrentBuilds: 5}
+	s.lookForSimulBuilds(context.Background())
+	s.goserver = &testGoserver{concurrentBuilds: 2}
+	s.lookForSimulBuilds(context.Background())
+	s.goserver = &testGoserver{concurrentBuilds: 5}
+	s.lookForSimulBuilds(context.Background())
+
+	if alertCount(s) != 0 {
+		t.Errorf("Error in alerting: %v", alertCount(s))
+	}
+
+	peak, average := s.buildSampleStats()
+	if peak != 5 || average != 4 {
+		t.Errorf("Bad sample stats: %v, %v", peak, average)
 	}
 }
 
 func TestGoVersionAlert(t *testing.T) {
 	s := InitTestServer()
 	s.lookForGoVersion(context.Background())
-	if s.alertCount != 1 {
-		t.Errorf("Error in alerting: %v", s.alertCount)
+	if alertCount(s) != 1 {
+		t.Errorf("Error in alerting: %v", alertCount(s))
 	}
 }
 
@@ -193,8 +291,8 @@ func TestGoVersionAlertMissing(t *testing.T) {
 	s := InitTestServer()
 	s.goserver = &testGoserver{reportsNormal: true}
 	s.lookForGoVersion(context.Background())
-	if s.alertCount != 1 {
-		t.Errorf("Error in alerting: %v", s.alertCount)
+	if alertCount(s) != 1 {
+		t.Errorf("Error in alerting: %v", alertCount(s))
 	}
 }
 
@@ -202,8 +300,8 @@ func TestGoVersionNoAlert(t *testing.T) {
 	s := InitTestServer()
 	s.goserver = &testGoserver{reportsNormal: true, goversion: true}
 	s.lookForGoVersion(context.Background())
-	if s.alertCount != 0 {
-		t.Errorf("Error in alerting: %v", s.alertCount)
+	if alertCount(s) != 0 {
+		t.Errorf("Error in alerting: %v", alertCount(s))
 	}
 }
 
@@ -289,3 +387,130 @@ func TestBasicProcessDiff(t *testing.T) {
 		t.Errorf("Basic eval failed: %v", err)
 	}
 }
+
+func TestStaleVersionAlert(t *testing.T) {
+	s := InitTestServer()
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	if _, ok := s.alerts.get("stale_version:madeup"); !ok {
+		t.Errorf("Stale version was not alerted on")
+	}
+}
+
+func TestStaleVersionInGracePeriod(t *testing.T) {
+	s := InitTestServer()
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	if _, ok := s.lastMismatchTime["madeup"]; !ok {
+		t.Errorf("Mismatch was not recorded")
+	}
+	if _, ok := s.alerts.get("stale_version:madeup"); ok {
+		t.Errorf("Stale version was alerted on within the grace period")
+	}
+}
+
+func TestStaleVersionJobOverride(t *testing.T) {
+	s := InitTestServer()
+	s.config.JobGracePeriods["madeup"] = int64(60 * 60 * 2)
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	if _, ok := s.alerts.get("stale_version:madeup"); ok {
+		t.Errorf("Stale version was alerted on within the job grace period")
+	}
+}
+
+func TestStaleVersionClears(t *testing.T) {
+	s := InitTestServer()
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runVersionCheck(context.Background(), time.Minute*20)
+	s.buildServer = &testBuildserver{match: true}
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	alert, ok := s.alerts.get("stale_version:madeup")
+	if !ok || alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Stale version did not clear: %v", alert)
+	}
+}
+
+func TestStaleVersionClosesIssue(t *testing.T) {
+	s := InitTestServer()
+	tracker := &testIssueTracker{opened: make(map[int32]string), closed: make(map[int32]string)}
+	s.issues = tracker
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	alert, _ := s.alerts.get("stale_version:madeup")
+	if alert.GetIssueNumber() == 0 || len(tracker.opened) != 1 {
+		t.Fatalf("Issue was not opened: %v", alert)
+	}
+
+	s.buildServer = &testBuildserver{match: true}
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	if note, ok := tracker.closed[alert.GetIssueNumber()]; !ok || !strings.Contains(note, "cleared after") {
+		t.Errorf("Issue was not closed: %v", tracker.closed)
+	}
+	if alert, _ = s.alerts.get("stale_version:madeup"); alert.GetIssueNumber() != 0 {
+		t.Errorf("Closed issue is still recorded: %v", alert)
+	}
+}
+
+func TestRepeatKeepsIssue(t *testing.T) {
+	s := InitTestServer()
+	tracker := &testIssueTracker{opened: make(map[int32]string), closed: make(map[int32]string)}
+	s.issues = tracker
+
+	s.alerts.fire(context.Background(), &pb.Alert{Check: "madeup", Title: "Problem", Body: "First"})
+	s.alerts.fire(context.Background(), &pb.Alert{Check: "madeup", Title: "Problem", Body: "Second", Severity: pb.Severity_CRITICAL})
+
+	if len(tracker.opened) != 1 {
+		t.Errorf("Repeat opened another issue: %v", tracker.opened)
+	}
+	if alert, _ := s.alerts.get("madeup"); alert.GetIssueNumber() != 1 {
+		t.Errorf("Issue number was replaced: %v", alert)
+	}
+}
+
+func TestResolveClosesIssueAfterRouteChange(t *testing.T) {
+	s := InitTestServer()
+	tracker := &testIssueTracker{opened: make(map[int32]string), closed: make(map[int32]string)}
+	s.issues = tracker
+
+	s.alerts.fire(context.Background(), &pb.Alert{Check: "madeup", Title: "Problem", Body: "Broken"})
+	s.config.Routes = []*pb.Route{&pb.Route{Check: "madeup", Severity: pb.Severity_WARNING, Action: pb.Route_LOG}}
+	s.alerts.pass(context.Background(), "madeup", "")
+
+	if _, ok := tracker.closed[1]; !ok {
+		t.Errorf("Issue was left open after the route changed: %v", tracker.closed)
+	}
+}
+
+func TestDriftBand(t *testing.T) {
+	if band := driftBand(time.Minute * 20); band != "under an hour" {
+		t.Errorf("Bad band: %v", band)
+	}
+	if band := driftBand(time.Hour*30 + time.Minute); band != driftBand(time.Hour*40) {
+		t.Errorf("Band changed within a day: %v vs %v", band, driftBand(time.Hour*40))
+	}
+	if band := driftBand(time.Hour * 24 * 8); band != "over a week" {
+		t.Errorf("Bad band: %v", band)
+	}
+}
+
+func TestStaleVersionResolvesWhenJobLeaves(t *testing.T) {
+	s := InitTestServer()
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	s.gobuildSlave = &testGobuildslave{jobs: []*pbgbs.JobAssignment{}}
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	if alert, _ := s.alerts.get("stale_version:madeup"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Stale version was not resolved once the job left: %v", alert)
+	}
+	if len(s.lastMismatchTime) != 0 {
+		t.Errorf("Mismatch was kept: %v", s.lastMismatchTime)
+	}
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// The number of events we keep against each alert
+	maxHistory = 20
+)
+
+// alertStore tracks the lifecycle of every alert the checks raise
+type alertStore struct {
+	mutex      *sync.Mutex
+	alerts     map[string]*pb.Alert
+	renotify   func() time.Duration
+	silenced   func(alert *pb.Alert) bool
+	raised     func(ctx context.Context, alert *pb.Alert)
+	resolved   func(ctx context.Context, alert *pb.Alert)
+	suppressed int64
+	raisedBy   map[string]int64
+}
+
+func newAlertStore(renotify func() time.Duration, silenced func(alert *pb.Alert) bool, raised, resolved func(ctx context.Context, alert *pb.Alert)) *alertStore {
+	return &alertStore{
+		mutex:    &sync.Mutex{},
+		alerts:   make(map[string]*pb.Alert),
+		raisedBy: make(map[string]int64),
+		renotify: renotify,
+		silenced: silenced,
+		raised:   raised,
+		resolved: resolved,
+	}
+}
+
+// fingerprint builds the stable key for a check and the subject it alerts on
+func fingerprint(check, subject string) string {
+	if len(subject) == 0 {
+		return check
+	}
+	return check + ":" + subject
+}
+
+func addEvent(alert *pb.Alert, body string) {
+	alert.History = append(alert.History, &pb.AlertEvent{Timestamp: time.Now().Unix(), Body: body, State: alert.GetState()})
+	if len(alert.History) > maxHistory {
+		alert.History = alert.History[len(alert.History)-maxHistory:]
+	}
+}
+
+// fire records that the check has failed for the subject. Repeats of an alert with the
+// same details and severity are only passed on once the renotify interval has passed,
+// and silenced alerts are not passed on at all.
+func (a *alertStore) fire(ctx context.Context, fired *pb.Alert) {
+	key := fingerprint(fired.GetCheck(), fired.GetSubject())
+	silenced := a.silenced(fired)
+
+	a.mutex.Lock()
+	alert, ok := a.alerts[key]
+	fresh := !ok || alert.GetState() == pb.Alert_RESOLVED
+	changed := alert.GetTitle() != fired.GetTitle() || alert.GetBody() != fired.GetBody() || alert.GetSeverity() != fired.GetSeverity()
+	if fresh {
+		if !ok {
+			alert = &pb.Alert{Key: key, Check: fired.GetCheck(), Subject: fired.GetSubject()}
+			a.alerts[key] = alert
+		}
+		alert.State = pb.Alert_FIRING
+		alert.FirstRaised = time.Now().Unix()
+		alert.AcknowledgedTime = 0
+		alert.ResolvedTime = 0
+	}
+	alert.Title = fired.GetTitle()
+	alert.Body = fired.GetBody()
+	alert.Severity = fired.GetSeverity()
+	alert.Labels = fired.GetLabels()
+	if task, ok := ctx.Value(taskKey{}).(string); ok {
+		alert.Task = task
+	}
+	alert.Silenced = silenced
+	alert.LastRaised = time.Now().Unix()
+	alert.Count++
+	addEvent(alert, fired.GetBody())
+
+	notify := false
+	if alert.GetState() == pb.Alert_FIRING && !silenced {
+		if fresh || changed || time.Since(time.Unix(alert.GetLastNotified(), 0)) >= a.renotify() {
+			notify = true
+			alert.LastNotified = time.Now().Unix()
+			a.raisedBy[fired.GetCheck()]++
+		} else {
+			alert.Suppressed++
+			a.suppressed++
+		}
+	}
+	raised := proto.Clone(alert).(*pb.Alert)
+	a.mutex.Unlock()
+
+	if notify {
+		a.raised(ctx, raised)
+	}
+}
+
+// pass records that the check has passed for the subject, resolving any open alert
+func (a *alertStore) pass(ctx context.Context, check, subject string) {
+	a.mutex.Lock()
+	alert, ok := a.alerts[fingerprint(check, subject)]
+	if !ok || alert.GetState() == pb.Alert_RESOLVED {
+		a.mutex.Unlock()
+		return
+	}
+	alert.State = pb.Alert_RESOLVED
+	alert.ResolvedTime = time.Now().Unix()
+	addEvent(alert, "Resolved")
+	resolved := proto.Clone(alert).(*pb.Alert)
+	a.mutex.Unlock()
+
+	a.resolved(ctx, resolved)
+}
+
+// passUnseen resolves every open alert for the check whose subject was not seen in this run
+func (a *alertStore) passUnseen(ctx context.Context, check string, seen map[string]bool) {
+	subjects := []string{}
+	a.mutex.Lock()
+	for _, alert := range a.alerts {
+		if alert.GetCheck() == check && alert.GetState() != pb.Alert_RESOLVED && !seen[alert.GetSubject()] {
+			subjects = append(subjects, alert.GetSubject())
+		}
+	}
+	a.mutex.Unlock()
+
+	for _, subject := range subjects {
+		a.pass(ctx, check, subject)
+	}
+}
+
+// resolveStale resolves every open alert that has not been raised within the age given for it
+func (a *alertStore) resolveStale(ctx context.Context, age func(alert *pb.Alert) time.Duration) {
+	open := []*pb.Alert{}
+	a.mutex.Lock()
+	for _, alert := range a.alerts {
+		if alert.GetState() != pb.Alert_RESOLVED {
+			open = append(open, proto.Clone(alert).(*pb.Alert))
+		}
+	}
+	a.mutex.Unlock()
+
+	for _, alert := range open {
+		if time.Since(time.Unix(alert.GetLastRaised(), 0)) > age(alert) {
+			a.pass(ctx, alert.GetCheck(), alert.GetSubject())
+		}
+	}
+}
+
+// acknowledge marks a firing alert as known about
+func (a *alertStore) acknowledge(key string) (*pb.Alert, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	alert, ok := a.alerts[key]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Unable to locate alert %v", key)
+	}
+	if alert.GetState() != pb.Alert_FIRING {
+		return nil, status.Errorf(codes.FailedPrecondition, "Alert %v is %v, not firing", key, alert.GetState())
+	}
+
+	alert.State = pb.Alert_ACKNOWLEDGED
+	alert.AcknowledgedTime = time.Now().Unix()
+	addEvent(alert, "Acknowledged")
+	return proto.Clone(alert).(*pb.Alert), nil
+}
+
+// snapshot copies every alert, history included
+func (a *alertStore) snapshot() []*pb.Alert {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	alerts := []*pb.Alert{}
+	for _, alert := range a.alerts {
+		alerts = append(alerts, proto.Clone(alert).(*pb.Alert))
+	}
+	return alerts
+}
+
+// restore replaces the alerts with those from a snapshot
+func (a *alertStore) restore(alerts []*pb.Alert) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.alerts = make(map[string]*pb.Alert)
+	for _, alert := range alerts {
+		a.alerts[alert.GetKey()] = alert
+	}
+}
+
+// setIssue records the issue opened for the alert
+func (a *alertStore) setIssue(key string, number int32) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if alert, ok := a.alerts[key]; ok {
+		alert.IssueNumber = number
+	}
+}
+
+// raisedCounts is the number of notifications each check has sent
+func (a *alertStore) raisedCounts() map[string]int64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	counts := make(map[string]int64)
+	for check, count := range a.raisedBy {
+		counts[check] = count
+	}
+	return counts
+}
+
+// openCount is the number of alerts that have not resolved
+func (a *alertStore) openCount() int64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	count := int64(0)
+	for _, alert := range a.alerts {
+		if alert.GetState() != pb.Alert_RESOLVED {
+			count++
+		}
+	}
+	return count
+}
+
+// suppressedCount is the number of repeat notifications we have held back
+func (a *alertStore) suppressedCount() int64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.suppressed
+}
+
+func (a *alertStore) get(key string) (*pb.Alert, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	alert, ok := a.alerts[key]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(alert).(*pb.Alert), true
+}
+
+// list returns the alerts without their history, most recently raised first
+func (a *alertStore) list(includeResolved bool) []*pb.Alert {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	alerts := []*pb.Alert{}
+	for _, alert := range a.alerts {
+		if includeResolved || alert.GetState() != pb.Alert_RESOLVED {
+			listed := proto.Clone(alert).(*pb.Alert)
+			listed.History = nil
+			alerts = append(alerts, listed)
+		}
+	}
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].GetLastRaised() > alerts[j].GetLastRaised()
+	})
+
+	return alerts
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+type testNotifications struct {
+	raised   []*pb.Alert
+	resolved []*pb.Alert
+	silenced bool
+}
+
+func (t *testNotifications) silence(alert *pb.Alert) bool {
+	return t.silenced
+}
+
+func (t *testNotifications) raise(ctx context.Context, alert *pb.Alert) {
+	t.raised = append(t.raised, alert)
+}
+
+func (t *testNotifications) resolve(ctx context.Context, alert *pb.Alert) {
+	t.resolved = append(t.resolved, alert)
+}
+
+func TestAlertLifecycle(t *testing.T) {
+	n := &testNotifications{}
+	store := newAlertStore(func() time.Duration { return time.Hour }, n.silence, n.raise, n.resolve)
+
+	store.fire(context.Background(), &pb.Alert{Check: "check", Subject: "subject", Title: "Problem", Body: "Broken"})
+	alert, ok := store.get("check:subject")
+	if !ok || alert.GetState() != pb.Alert_FIRING || len(n.raised) != 1 {
+		t.Fatalf("Alert was not fired: %v", alert)
+	}
+
+	store.pass(context.Background(), "check", "subject")
+	alert, ok = store.get("check:subject")
+	if !ok || alert.GetState() != pb.Alert_RESOLVED || len(n.resolved) != 1 {
+		t.Errorf("Alert was not resolved: %v", alert)
+	}
+
+	if len(store.list(false)) != 0 || len(store.list(true)) != 1 {
+		t.Errorf("Resolved alert is still listed as active: %v", store.list(false))
+	}
+}
+
+func TestAlertPassWithoutFire(t *testing.T) {
+	n := &testNotifications{}
+	store := newAlertStore(func() time.Duration { return time.Hour }, n.silence, n.raise, n.resolve)
+
+	store.pass(context.Background(), "check", "subject")
+	if len(n.resolved) != 0 {
+		t.Errorf("Resolution was emitted for an unknown alert: %v", n.resolved)
+	}
+}
+
+func TestAlertAcknowledgeSilencesRefire(t *testing.T) {
+	n := &testNotifications{}
+	store := newAlertStore(func() time.Duration { return time.Hour }, n.silence, n.raise, n.resolve)
+
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+	if _, err := store.acknowledge("check"); err != nil {
+		t.Fatalf("Unable to acknowledge: %v", err)
+	}
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+
+	alert, _ := store.get("check")
+	if alert.GetState() != pb.Alert_ACKNOWLEDGED || len(n.raised) != 1 {
+		t.Errorf("Acknowledged alert was raised again: %v", alert)
+	}
+
+	if _, err := store.acknowledge("check"); err == nil {
+		t.Errorf("Acknowledged an alert twice")
+	}
+}
+
+func TestAlertRefiresAfterResolution(t *testing.T) {
+	n := &testNotifications{}
+	store := newAlertStore(func() time.Duration { return time.Hour }, n.silence, n.raise, n.resolve)
+
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+	store.pass(context.Background(), "check", "")
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken again"})
+
+	alert, _ := store.get("check")
+	if alert.GetState() != pb.Alert_FIRING || alert.GetResolvedTime() != 0 || len(n.raised) != 2 {
+		t.Errorf("Alert did not refire: %v", alert)
+	}
+}
+
+func TestAlertDeduplicated(t *testing.T) {
+	n := &testNotifications{}
+	store := newAlertStore(func() time.Duration { return time.Hour }, n.silence, n.raise, n.resolve)
+
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+
+	if len(n.raised) != 1 || store.suppressedCount() != 1 {
+		t.Errorf("Repeat was not suppressed: %v, %v", n.raised, store.suppressedCount())
+	}
+}
+
+func TestAlertRenotifiesOnChange(t *testing.T) {
+	n := &testNotifications{}
+	store := newAlertStore(func() time.Duration { return time.Hour }, n.silence, n.raise, n.resolve)
+
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken differently"})
+
+	if len(n.raised) != 2 || store.suppressedCount() != 0 {
+		t.Errorf("Changed alert was suppressed: %v, %v", n.raised, store.suppressedCount())
+	}
+}
+
+func TestAlertRenotifiesAfterInterval(t *testing.T) {
+	n := &testNotifications{}
+	store := newAlertStore(func() time.Duration { return 0 }, n.silence, n.raise, n.resolve)
+
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+
+	if len(n.raised) != 2 {
+		t.Errorf("Alert was not renotified: %v", n.raised)
+	}
+}
+
+func TestAlertSilenced(t *testing.T) {
+	n := &testNotifications{silenced: true}
+	store := newAlertStore(func() time.Duration { return time.Hour }, n.silence, n.raise, n.resolve)
+
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+	if len(n.raised) != 0 {
+		t.Fatalf("Silenced alert was raised: %v", n.raised)
+	}
+
+	n.silenced = false
+	store.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken"})
+	if len(n.raised) != 1 {
+		t.Errorf("Alert was not raised once the silence ended: %v", n.raised)
+	}
+}
+
+func TestRaisedCountsNotifications(t *testing.T) {
+	n := &testNotifications{}
+	store := newAlertStore(func() time.Duration { return time.Hour }, n.silence, n.raise, n.resolve)
+
+	store.fire(context.Background(), &pb.Alert{Check: "check", Subject: "one", Title: "Problem", Body: "Broken"})
+	store.fire(context.Background(), &pb.Alert{Check: "check", Subject: "one", Title: "Problem", Body: "Broken"})
+	n.silenced = true
+	store.fire(context.Background(), &pb.Alert{Check: "check", Subject: "two", Title: "Problem", Body: "Broken"})
+
+	if counts := store.raisedCounts(); counts["check"] != int64(len(n.raised)) || counts["check"] != 1 {
+		t.Errorf("Suppressed or silenced alerts were counted: %v, %v", counts, n.raised)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+// apiDiff describes how the V2 listings from the friends disagree with the V1 registry over an entry
+type apiDiff struct {
+	key string
+
+	// The friends whose V2 listing lacks an entry that V1 holds
+	missing []string
+
+	// The friends whose V2 listing holds an entry that V1 lacks
+	extra []string
+
+	// The friends whose V2 listing holds the entry with a different address or master flag
+	different []string
+}
+
+func (a *apiDiff) String() string {
+	parts := []string{}
+	if len(a.missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing from V2 on %v", strings.Join(a.missing, ", ")))
+	}
+	if len(a.extra) > 0 {
+		parts = append(parts, fmt.Sprintf("missing from V1 but held by V2 on %v", strings.Join(a.extra, ", ")))
+	}
+	if len(a.different) > 0 {
+		parts = append(parts, fmt.Sprintf("different in V2 on %v", strings.Join(a.different, ", ")))
+	}
+	return fmt.Sprintf("%v is %v", a.key, strings.Join(parts, "; "))
+}
+
+// sameEndpoint ignores the bookkeeping fields each API is free to report differently
+func sameEndpoint(a, b *pbd.RegistryEntry) bool {
+	return a.Ip == b.Ip && a.Port == b.Port && a.Master == b.Master
+}
+
+// diffAPIs compares the V1 registry against the V2 listing from each friend
+func diffAPIs(registry []*pbd.RegistryEntry, listings map[string][]*pbd.RegistryEntry) []*apiDiff {
+	friends := []string{}
+	for friend := range listings {
+		friends = append(friends, friend)
+	}
+	sort.Strings(friends)
+
+	keys := []string{}
+	known := make(map[string]bool)
+	addKey := func(key string) {
+		if !known[key] {
+			known[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	v1 := make(map[string]*pbd.RegistryEntry)
+	for _, entry := range registry {
+		addKey(entryKey(entry))
+		v1[entryKey(entry)] = entry
+	}
+
+	v2 := make(map[string]map[string]*pbd.RegistryEntry)
+	for _, friend := range friends {
+		v2[friend] = make(map[string]*pbd.RegistryEntry)
+		for _, entry := range listings[friend] {
+			addKey(entryKey(entry))
+			v2[friend][entryKey(entry)] = entry
+		}
+	}
+	sort.Strings(keys)
+
+	diffs := []*apiDiff{}
+	for _, key := range keys {
+		diff := &apiDiff{key: key}
+		entry, inV1 := v1[key]
+		for _, friend := range friends {
+			other, inV2 := v2[friend][key]
+			switch {
+			case inV1 && !inV2:
+				diff.missing = append(diff.missing, friend)
+			case !inV1 && inV2:
+				diff.extra = append(diff.extra, friend)
+			case inV1 && inV2 && !sameEndpoint(entry, other):
+				diff.different = append(diff.different, friend)
+			}
+		}
+
+		if len(diff.missing) > 0 || len(diff.extra) > 0 || len(diff.different) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs
+}
+
+// compareAPIs checks that discovery's V1 and V2 APIs agree on the registry
+func (s *Server) compareAPIs(ctx context.Context) (time.Time, error) {
+	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+
+	friends, seed, err := s.discover.getFriends(ctx)
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+
+	// We only compare complete listings, evaluate_friends reports the friends we can't reach
+	listings := make(map[string][]*pbd.RegistryEntry)
+	for _, friend := range parseFriends(friends) {
+		list, err := s.discover.list(ctx, friend)
+		if err == nil {
+			listings[friend] = list
+		}
+	}
+	s.recordExamined(ctx, len(listings), 0)
+
+	seen := make(map[string]bool)
+	for _, diff := range diffAPIs(serv.GetServices().GetServices(), listings) {
+		seen[diff.key] = true
+		s.alerts.fire(ctx, &pb.Alert{Check: "api_divergence", Subject: diff.key, Labels: map[string]string{"service": "discovery", "seed": seed, "entry": diff.key}, Severity: pb.Severity_WARNING, Title: "Discovery API Divergence",
+			Body: fmt.Sprintf("V1 and V2 disagree: %v", diff)})
+	}
+	s.alerts.passUnseen(ctx, "api_divergence", seen)
+
+	return time.Now().Add(time.Minute * 5), nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+func TestDiffAPIs(t *testing.T) {
+	diffs := diffAPIs(
+		[]*pbd.RegistryEntry{
+			&pbd.RegistryEntry{Identifier: "alpha", Name: "same", Ip: "10.0.0.1", Port: 1},
+			&pbd.RegistryEntry{Identifier: "alpha", Name: "moved", Ip: "10.0.0.1", Port: 2},
+			&pbd.RegistryEntry{Identifier: "alpha", Name: "lost", Ip: "10.0.0.1", Port: 3},
+		},
+		map[string][]*pbd.RegistryEntry{
+			"friend": []*pbd.RegistryEntry{
+				&pbd.RegistryEntry{Identifier: "alpha", Name: "same", Ip: "10.0.0.1", Port: 1, RegisterTime: 12},
+				&pbd.RegistryEntry{Identifier: "alpha", Name: "moved", Ip: "10.0.0.1", Port: 4},
+				&pbd.RegistryEntry{Identifier: "alpha", Name: "found", Ip: "10.0.0.1", Port: 5},
+			},
+		})
+
+	if len(diffs) != 3 {
+		t.Fatalf("Wrong number of diffs: %v", diffs)
+	}
+	if diffs[0].key != "alpha/found" || len(diffs[0].extra) != 1 {
+		t.Errorf("Extra entry was not found: %v", diffs[0])
+	}
+	if diffs[1].key != "alpha/lost" || len(diffs[1].missing) != 1 {
+		t.Errorf("Missing entry was not found: %v", diffs[1])
+	}
+	if diffs[2].key != "alpha/moved" || len(diffs[2].different) != 1 {
+		t.Errorf("Different entry was not found: %v", diffs[2])
+	}
+}
+
+func TestCompareAPIs(t *testing.T) {
+	s := InitTestServer()
+	s.discover = &testDiscovery{services: []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "one"}}}
+
+	s.compareAPIs(context.Background())
+
+	alert, ok := s.alerts.get("api_divergence:two/")
+	if !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Errorf("Divergence was not raised: %v", alert)
+	}
+	if alert, ok := s.alerts.get("api_divergence:one/"); ok {
+		t.Errorf("Matching entry was raised: %v", alert)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+// collision describes registry entries that should not coexist
+type collision struct {
+	key         string
+	description string
+
+	// Where we saw the collision, either the registry or a discovery friend
+	sources []string
+}
+
+// findCollisions looks for entries registered more than once, entries sharing an endpoint,
+// identities registered on several ports and entries without an address, returning the
+// collisions keyed on what collides
+func findCollisions(entries []*pbd.RegistryEntry) map[string]string {
+	endpoints := make(map[string][]string)
+	ports := make(map[string][]string)
+	registered := make(map[string]int)
+	collisions := make(map[string]string)
+
+	for _, entry := range entries {
+		identity := entry.Identifier + "/" + entry.Name
+		if len(entry.Ip) == 0 {
+			collisions["empty_ip:"+identity] = fmt.Sprintf("%v is registered without an ip", identity)
+			continue
+		}
+
+		// A repeated entry collides only with itself, so it shouldn't also show up as
+		// a clash of endpoints or ports
+		endpoint := fmt.Sprintf("%v:%v", entry.Ip, entry.Port)
+		registered[identity+"@"+endpoint]++
+		if registered[identity+"@"+endpoint] > 1 {
+			collisions["duplicate:"+identity+"@"+endpoint] = fmt.Sprintf("%v is registered %v times on %v", identity, registered[identity+"@"+endpoint], endpoint)
+			continue
+		}
+
+		endpoints[endpoint] = append(endpoints[endpoint], identity)
+		ports[identity] = append(ports[identity], fmt.Sprintf("%v", entry.Port))
+	}
+
+	for endpoint, identities := range endpoints {
+		if len(identities) > 1 {
+			sort.Strings(identities)
+			collisions["endpoint:"+endpoint] = fmt.Sprintf("%v is claimed by %v", endpoint, strings.Join(identities, ", "))
+		}
+	}
+	for identity, onPorts := range ports {
+		if len(onPorts) > 1 {
+			sort.Strings(onPorts)
+			collisions["identity:"+identity] = fmt.Sprintf("%v is registered on ports %v", identity, strings.Join(onPorts, ", "))
+		}
+	}
+
+	return collisions
+}
+
+// lookForCollisions checks the registry and every friend's listing, raising one alert per collision
+func (s *Server) lookForCollisions(ctx context.Context) (time.Time, error) {
+	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+
+	sources := []string{"registry"}
+	listings := map[string][]*pbd.RegistryEntry{"registry": serv.GetServices().GetServices()}
+
+	// Friend failures are reported by evaluate_friends, so we check what we can
+	friends, _, err := s.discover.getFriends(ctx)
+	if err == nil {
+		strFriends := parseFriends(friends)
+		sort.Strings(strFriends)
+		for _, friend := range strFriends {
+			list, err := s.discover.list(ctx, friend)
+			if err == nil {
+				sources = append(sources, friend)
+				listings[friend] = list
+			}
+		}
+	}
+	s.recordExamined(ctx, len(sources), 0)
+
+	collisions := make(map[string]*collision)
+	for _, source := range sources {
+		for key, description := range findCollisions(listings[source]) {
+			if _, ok := collisions[key]; !ok {
+				collisions[key] = &collision{key: key, description: description}
+			}
+			collisions[key].sources = append(collisions[key].sources, source)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for key, found := range collisions {
+		seen[key] = true
+		s.alerts.fire(ctx, &pb.Alert{Check: "registry_collision", Subject: key, Labels: map[string]string{"service": "discovery", "entry": key}, Severity: pb.Severity_WARNING, Title: "Registry Collision",
+			Body: fmt.Sprintf("%v (seen in %v)", found.description, strings.Join(found.sources, ", "))})
+	}
+	s.alerts.passUnseen(ctx, "registry_collision", seen)
+
+	return time.Now().Add(time.Minute * 5), nil
+}
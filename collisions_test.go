@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+func TestFindCollisions(t *testing.T) {
+	collisions := findCollisions([]*pbd.RegistryEntry{
+		&pbd.RegistryEntry{Identifier: "alpha", Name: "one", Ip: "10.0.0.1", Port: 1},
+		&pbd.RegistryEntry{Identifier: "alpha", Name: "two", Ip: "10.0.0.1", Port: 1},
+		&pbd.RegistryEntry{Identifier: "alpha", Name: "two", Ip: "10.0.0.1", Port: 2},
+		&pbd.RegistryEntry{Identifier: "beta", Name: "three"},
+		&pbd.RegistryEntry{Identifier: "beta", Name: "four", Ip: "10.0.0.2", Port: 1},
+	})
+
+	for _, key := range []string{"endpoint:10.0.0.1:1", "identity:alpha/two", "empty_ip:beta/three"} {
+		if _, ok := collisions[key]; !ok {
+			t.Errorf("Missing collision %v: %v", key, collisions)
+		}
+	}
+	if len(collisions) != 3 {
+		t.Errorf("Wrong number of collisions: %v", collisions)
+	}
+}
+
+func TestDuplicateEntryIsOneCollision(t *testing.T) {
+	collisions := findCollisions([]*pbd.RegistryEntry{
+		&pbd.RegistryEntry{Identifier: "alpha", Name: "one", Ip: "10.0.0.1", Port: 1},
+		&pbd.RegistryEntry{Identifier: "alpha", Name: "one", Ip: "10.0.0.1", Port: 1},
+	})
+
+	if _, ok := collisions["duplicate:alpha/one@10.0.0.1:1"]; !ok || len(collisions) != 1 {
+		t.Errorf("Duplicate entry was not reported as a single collision: %v", collisions)
+	}
+}
+
+func TestCollisionsAreConsolidated(t *testing.T) {
+	s := InitTestServer()
+	s.discover = &testDiscovery{services: []*pbd.RegistryEntry{
+		&pbd.RegistryEntry{Identifier: "one"},
+	}}
+
+	s.lookForCollisions(context.Background())
+
+	alert, ok := s.alerts.get("registry_collision:empty_ip:one/")
+	if !ok {
+		t.Fatalf("Collision was not raised: %v", s.alerts.list(true))
+	}
+	if !strings.Contains(alert.GetBody(), "registry, deps, yeps") {
+		t.Errorf("Collision was not consolidated across sources: %v", alert.GetBody())
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// CONFIG is where we store the alerter config in keystore
+	CONFIG = "github.com/brotherlogic/alerter/config"
+
+	// How often every alerter reloads its config
+	configRefresh = time.Minute * 5
+
+	// The discovery server we find friends through when nothing else is configured
+	defaultSeed = "192.168.86.49:50055"
+)
+
+func defaultConfig() *pb.Config {
+	return &pb.Config{
+		JobGracePeriods: make(map[string]int64),
+		GoPolicy:        &pb.GoPolicy{MinVersion: "go1.11.6"},
+
+		ConcurrentBuildsThreshold: 4,
+		ConcurrentBuildsWindow:    3,
+
+		RenotifyInterval: 60 * 60 * 24,
+		DigestInterval:   60 * 60 * 24,
+		StaleAlertAge:    60 * 60,
+		HealthMultiple:   3,
+
+		CrashLoopRestarts:   3,
+		CrashLoopWindow:     60 * 60,
+		StuckJobGracePeriod: 60 * 15,
+		MasterGracePeriod:   60 * 5,
+
+		StartTimeKey:      "startup_time",
+		ZombieGracePeriod: 60 * 5,
+		ZombieClockSkew:   60,
+	}
+}
+
+// getConfig gets the current config, which is never changed once it is in place
+func (s *Server) getConfig() *pb.Config {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+	return s.config
+}
+
+func (s *Server) setConfig(config *pb.Config) {
+	s.configMutex.Lock()
+	defer s.configMutex.Unlock()
+	s.config = config
+}
+
+// loadConfig refreshes the config from the config file if we have one, otherwise from
+// keystore, keeping the current one if the read fails. Unset fields take their defaults.
+func (s *Server) loadConfig(ctx context.Context) error {
+	read := &pb.Config{}
+	if len(s.configFile) > 0 {
+		data, err := ioutil.ReadFile(s.configFile)
+		if err != nil {
+			return err
+		}
+
+		err = proto.UnmarshalText(string(data), read)
+		if err != nil {
+			return err
+		}
+	} else {
+		data, _, err := s.KSclient.Read(ctx, CONFIG, &pb.Config{})
+		if err != nil {
+			return err
+		}
+		read = data.(*pb.Config)
+	}
+
+	s.setConfig(withDefaults(read))
+	return nil
+}
+
+// withDefaults fills in each top level field the config leaves unset from the defaults. A
+// field that is set replaces its default whole, so a go policy without a minimum version
+// has no minimum. Numbers, flags and strings can't be set back to zero, false or empty,
+// since proto3 reads those as unset.
+func withDefaults(config *pb.Config) *pb.Config {
+	merged := proto.Clone(config).(*pb.Config)
+	fields := reflect.ValueOf(merged).Elem()
+	defaults := reflect.ValueOf(defaultConfig()).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		if strings.HasPrefix(fields.Type().Field(i).Name, "XXX_") {
+			continue
+		}
+
+		unset := false
+		switch field.Kind() {
+		case reflect.Map, reflect.Slice:
+			unset = field.Len() == 0
+		default:
+			unset = field.Interface() == reflect.Zero(field.Type()).Interface()
+		}
+		if unset {
+			field.Set(defaults.Field(i))
+		}
+	}
+	return merged
+}
+
+// refreshConfig keeps the config up to date on every alerter, master or not, until the
+// context is done
+func (s *Server) refreshConfig(ctx context.Context) {
+	for {
+		if err := s.loadConfig(ctx); err != nil {
+			s.Log(fmt.Sprintf("Unable to load config: %v", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(configRefresh):
+		}
+	}
+}
+
+// versionGracePeriod is how long the given job may run a stale version
+func (s *Server) versionGracePeriod(job string, delay time.Duration) time.Duration {
+	if grace, ok := s.getConfig().GetJobGracePeriods()[job]; ok {
+		return time.Duration(grace) * time.Second
+	}
+	if s.getConfig().GetVersionGracePeriod() > 0 {
+		return time.Duration(s.getConfig().GetVersionGracePeriod()) * time.Second
+	}
+	return delay
+}
+
+// renotifyInterval is how long we wait before repeating an unchanged alert
+func (s *Server) renotifyInterval() time.Duration {
+	return time.Duration(s.getConfig().GetRenotifyInterval()) * time.Second
+}
+
+// discoverySeeds are the discovery servers to try, taking the flag over the config
+func (s *Server) discoverySeeds() []string {
+	if len(s.seeds) > 0 {
+		return s.seeds
+	}
+	if len(s.getConfig().GetDiscoverySeeds()) > 0 {
+		return s.getConfig().GetDiscoverySeeds()
+	}
+	return []string{defaultSeed}
+}
+
+// trySeeds asks each seed in turn, returning the first answer and the seed that gave it
+func trySeeds(seeds []string, ask func(seed string) (string, error)) (string, string, error) {
+	if len(seeds) == 0 {
+		return "", "", status.Errorf(codes.FailedPrecondition, "No discovery seeds are configured")
+	}
+
+	failures := []string{}
+	notReady := true
+	for _, seed := range seeds {
+		answer, err := ask(seed)
+		if err == nil {
+			return answer, seed, nil
+		}
+		failures = append(failures, fmt.Sprintf("%v: %v", seed, err))
+		notReady = notReady && status.Convert(err).Code() == codes.FailedPrecondition
+	}
+
+	// Only report not ready if none of the seeds could have answered
+	if notReady {
+		return "", "", status.Errorf(codes.FailedPrecondition, "No seed is ready to serve: %v", failures)
+	}
+	return "", "", status.Errorf(codes.Unavailable, "Unable to reach any seed: %v", failures)
+}
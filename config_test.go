@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbg "github.com/brotherlogic/goserver/proto"
+)
+
+// testFriendServer serves the goserver state of a discovery friend
+type testFriendServer struct {
+	pbg.GoserverServiceServer
+	friends string
+}
+
+func (t *testFriendServer) State(ctx context.Context, req *pbg.Empty) (*pbg.ServerState, error) {
+	return &pbg.ServerState{States: []*pbg.State{
+		&pbg.State{Key: "ftime", TimeValue: time.Now().Unix()},
+		&pbg.State{Key: "friends", Text: t.friends},
+	}}, nil
+}
+
+func TestDiscoverySeeds(t *testing.T) {
+	s := InitTestServer()
+	if seeds := s.discoverySeeds(); len(seeds) != 1 || seeds[0] != defaultSeed {
+		t.Errorf("Bad default seeds: %v", seeds)
+	}
+
+	s.config.DiscoverySeeds = []string{"config:50055"}
+	if seeds := s.discoverySeeds(); len(seeds) != 1 || seeds[0] != "config:50055" {
+		t.Errorf("Bad config seeds: %v", seeds)
+	}
+
+	s.seeds = []string{"flag:50055"}
+	if seeds := s.discoverySeeds(); len(seeds) != 1 || seeds[0] != "flag:50055" {
+		t.Errorf("Bad flag seeds: %v", seeds)
+	}
+}
+
+func TestTrySeedsFailover(t *testing.T) {
+	friends, seed, err := trySeeds([]string{"down", "up"}, func(seed string) (string, error) {
+		if seed == "down" {
+			return "", fmt.Errorf("Built to fail")
+		}
+		return "yeps deps", nil
+	})
+
+	if err != nil || seed != "up" || friends != "yeps deps" {
+		t.Errorf("Bad failover: %v, %v, %v", friends, seed, err)
+	}
+}
+
+func TestTrySeedsAllFail(t *testing.T) {
+	_, _, err := trySeeds([]string{"one", "two"}, func(seed string) (string, error) {
+		return "", fmt.Errorf("Built to fail")
+	})
+
+	if status.Convert(err).Code() != codes.Unavailable {
+		t.Errorf("Bad error: %v", err)
+	}
+}
+
+func TestTrySeedsNotReady(t *testing.T) {
+	_, _, err := trySeeds([]string{"one", "two"}, func(seed string) (string, error) {
+		return "", status.Errorf(codes.FailedPrecondition, "Not ready")
+	})
+
+	if status.Convert(err).Code() != codes.FailedPrecondition {
+		t.Errorf("Bad error: %v", err)
+	}
+}
+
+func TestProdDiscoveryUnreachableSeeds(t *testing.T) {
+	p := &prodDiscovery{seeds: func() []string { return []string{"127.0.0.1:1", "127.0.0.1:2"} }}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, seed, err := p.getFriends(ctx)
+	if err == nil {
+		t.Errorf("Should have failed but got an answer from %v", seed)
+	}
+}
+
+func TestProdDiscoveryFailsOver(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	pbg.RegisterGoserverServiceServer(server, &testFriendServer{friends: "192.168.86.1 192.168.86.2"})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	p := &prodDiscovery{seeds: func() []string { return []string{"127.0.0.1:1", lis.Addr().String()} }}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	friends, seed, err := p.getFriends(ctx)
+	if err != nil {
+		t.Fatalf("Unable to get friends: %v", err)
+	}
+	if seed != lis.Addr().String() || friends != "192.168.86.1 192.168.86.2" {
+		t.Errorf("Bad failover: %v from %v", friends, seed)
+	}
+}
+
+func TestLoadConfigFromFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "alerter-config")
+	if err != nil {
+		t.Fatalf("Unable to create config file: %v", err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString("concurrent_builds_threshold: 10\n")
+	file.Close()
+
+	s := InitTestServer()
+	s.configFile = file.Name()
+	err = s.loadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("Unable to load config: %v", err)
+	}
+
+	if s.getConfig().GetConcurrentBuildsThreshold() != 10 || s.getConfig().GetRenotifyInterval() != defaultConfig().GetRenotifyInterval() {
+		t.Errorf("Config was not merged over the defaults: %v", s.getConfig())
+	}
+}
+
+func TestConfigReplacesDefaultGoPolicy(t *testing.T) {
+	config := withDefaults(&pb.Config{GoPolicy: &pb.GoPolicy{AllowedVersions: []string{"go1.13.4"}}, DiscoverySeeds: []string{"a"}})
+
+	if len(config.GetGoPolicy().GetMinVersion()) > 0 || len(config.GetGoPolicy().GetAllowedVersions()) != 1 {
+		t.Errorf("Go policy was merged with the default: %v", config.GetGoPolicy())
+	}
+	if config.GetRenotifyInterval() != defaultConfig().GetRenotifyInterval() || config.GetDiscoverySeeds()[0] != "a" {
+		t.Errorf("Unset fields did not take their defaults: %v", config)
+	}
+}
+
+func TestConfigRefreshWhileTasksRun(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan bool)
+	go func() {
+		s.refreshConfig(ctx)
+		close(done)
+	}()
+	s.runTask(context.Background(), "look_for_simul_builds")
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Errorf("Config refresh did not stop")
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pbd "github.com/brotherlogic/discovery/proto"
+	"github.com/golang/protobuf/proto"
+)
+
+// entryDiff describes how the discovery friends disagree over a single registry entry
+type entryDiff struct {
+	key string
+
+	// The friends holding the most common version of the entry
+	have []string
+
+	// The friends without the entry
+	lack []string
+
+	// The friends holding some other version of the entry
+	different []string
+}
+
+func (e *entryDiff) String() string {
+	return fmt.Sprintf("%v is held by %v, missing from %v and different on %v", e.key, e.have, e.lack, e.different)
+}
+
+// entryKey identifies an entry across listings, matching the identities collisions reports
+func entryKey(entry *pbd.RegistryEntry) string {
+	return entry.Identifier + "/" + entry.Name
+}
+
+// parseFriends splits the friends state text into the friend addresses
+func parseFriends(friends string) []string {
+	return strings.Fields(strings.Replace(strings.Replace(friends, "[", "", -1), "]", "", -1))
+}
+
+// diffListings compares every friend's listing against every other, returning the entries they disagree on
+func diffListings(listings map[string][]*pbd.RegistryEntry) []*entryDiff {
+	friends := []string{}
+	for friend := range listings {
+		friends = append(friends, friend)
+	}
+	sort.Strings(friends)
+
+	keys := []string{}
+	held := make(map[string]map[string]*pbd.RegistryEntry)
+	for _, friend := range friends {
+		for _, entry := range listings[friend] {
+			key := entryKey(entry)
+			if _, ok := held[key]; !ok {
+				held[key] = make(map[string]*pbd.RegistryEntry)
+				keys = append(keys, key)
+			}
+			held[key][friend] = entry
+		}
+	}
+	sort.Strings(keys)
+
+	diffs := []*entryDiff{}
+	for _, key := range keys {
+		// Pick the version of the entry held by the most friends
+		var best *pbd.RegistryEntry
+		bestCount := 0
+		for _, friend := range friends {
+			if entry, ok := held[key][friend]; ok {
+				count := 0
+				for _, other := range held[key] {
+					if proto.Equal(entry, other) {
+						count++
+					}
+				}
+				if count > bestCount {
+					best = entry
+					bestCount = count
+				}
+			}
+		}
+
+		diff := &entryDiff{key: key}
+		for _, friend := range friends {
+			entry, ok := held[key][friend]
+			switch {
+			case !ok:
+				diff.lack = append(diff.lack, friend)
+			case proto.Equal(entry, best):
+				diff.have = append(diff.have, friend)
+			default:
+				diff.different = append(diff.different, friend)
+			}
+		}
+
+		if len(diff.lack) > 0 || len(diff.different) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+func TestParseFriends(t *testing.T) {
+	friends := parseFriends("[192.168.86.1:50055 192.168.86.2:50055]")
+	if len(friends) != 2 || friends[0] != "192.168.86.1:50055" || friends[1] != "192.168.86.2:50055" {
+		t.Errorf("Bad parse: %v", friends)
+	}
+}
+
+func TestDiffListingsAgree(t *testing.T) {
+	diffs := diffListings(map[string][]*pbd.RegistryEntry{
+		"one":   []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "a", Name: "s"}},
+		"two":   []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "a", Name: "s"}},
+		"three": []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "a", Name: "s"}},
+	})
+
+	if len(diffs) != 0 {
+		t.Errorf("Found diffs in matching listings: %v", diffs)
+	}
+}
+
+func TestDiffListingsKeepsEntriesApart(t *testing.T) {
+	diffs := diffListings(map[string][]*pbd.RegistryEntry{
+		"one": []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "ab", Name: "c"}},
+		"two": []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "a", Name: "bc"}},
+	})
+
+	if len(diffs) != 2 {
+		t.Errorf("Distinct entries were merged: %v", diffs)
+	}
+}
+
+func TestDiffListingsSplitBrain(t *testing.T) {
+	diffs := diffListings(map[string][]*pbd.RegistryEntry{
+		"one":   []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "a", Name: "s", Port: 1}, &pbd.RegistryEntry{Identifier: "b", Name: "s"}},
+		"two":   []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "a", Name: "s", Port: 1}},
+		"three": []*pbd.RegistryEntry{&pbd.RegistryEntry{Identifier: "a", Name: "s", Port: 2}, &pbd.RegistryEntry{Identifier: "b", Name: "s"}},
+	})
+
+	if len(diffs) != 2 {
+		t.Fatalf("Wrong number of diffs: %v", diffs)
+	}
+
+	if diffs[0].key != "a/s" || len(diffs[0].have) != 2 || len(diffs[0].different) != 1 || diffs[0].different[0] != "three" {
+		t.Errorf("Bad diff on a: %v", diffs[0])
+	}
+
+	if diffs[1].key != "b/s" || len(diffs[1].lack) != 1 || diffs[1].lack[0] != "two" {
+		t.Errorf("Bad diff on b: %v", diffs[1])
+	}
+}
+
+func TestBasicProcessDiffResolves(t *testing.T) {
+	s := InitTestServer()
+	s.discover = &testDiscovery{diff: true}
+	s.evaluateFriends(context.Background())
+
+	s.discover = &testDiscovery{}
+	_, err := s.evaluateFriends(context.Background())
+	if err != nil {
+		t.Fatalf("Evaluation failed: %v", err)
+	}
+
+	if len(s.alerts.list(false)) != 0 {
+		t.Errorf("Diffs were not resolved: %v", s.alerts.list(false))
+	}
+}
+
+func TestSeedFailoverKeepsAlerts(t *testing.T) {
+	s := InitTestServer()
+	s.discover = &testDiscovery{diff: true, seed: "first"}
+	s.evaluateFriends(context.Background())
+
+	s.discover = &testDiscovery{diff: true, seed: "second"}
+	s.evaluateFriends(context.Background())
+
+	alerts := s.alerts.list(false)
+	if len(alerts) == 0 {
+		t.Fatalf("Diffs were not raised")
+	}
+	for _, alert := range alerts {
+		if alert.GetSuppressed() != 1 || alert.GetLabels()["seed"] != "second" {
+			t.Errorf("Seed failover renotified or lost the seed: %v", alert)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var goVersionRegex = regexp.MustCompile(`^go(\d+)(?:\.(\d+))?(?:\.(\d+))?((?:beta|rc)\d+)?$`)
+
+// goVersion is a parsed go toolchain version, e.g. go1.11.6
+type goVersion struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+func parseGoVersion(version string) (*goVersion, error) {
+	matches := goVersionRegex.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, fmt.Errorf("%v is not a go version", version)
+	}
+
+	parsed := &goVersion{prerelease: matches[4]}
+	parsed.major, _ = strconv.Atoi(matches[1])
+	if len(matches[2]) > 0 {
+		parsed.minor, _ = strconv.Atoi(matches[2])
+	}
+	if len(matches[3]) > 0 {
+		parsed.patch, _ = strconv.Atoi(matches[3])
+	}
+	return parsed, nil
+}
+
+// compare returns -1, 0 or 1 depending on whether g is before, the same as or after o
+func (g *goVersion) compare(o *goVersion) int {
+	for _, diff := range []int{g.major - o.major, g.minor - o.minor, g.patch - o.patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+
+	// A prerelease comes before the release itself
+	switch {
+	case g.prerelease == o.prerelease:
+		return 0
+	case len(g.prerelease) == 0:
+		return 1
+	case len(o.prerelease) == 0:
+		return -1
+	case g.prerelease < o.prerelease:
+		return -1
+	}
+	return 1
+}
+
+// sameRelease is true if both versions are patches of the same go release
+func (g *goVersion) sameRelease(o *goVersion) bool {
+	return g.major == o.major && g.minor == o.minor
+}
+
+// checkGoVersion returns an error explaining why the version breaks the policy
+func (s *Server) checkGoVersion(version, buildserverVersion string) error {
+	policy := s.getConfig().GetGoPolicy()
+
+	parsed, err := parseGoVersion(version)
+	if err != nil {
+		return err
+	}
+
+	if len(policy.GetMinVersion()) > 0 {
+		min, err := parseGoVersion(policy.GetMinVersion())
+		if err != nil {
+			return fmt.Errorf("Bad minimum version in policy: %v", err)
+		}
+		if parsed.compare(min) < 0 {
+			return fmt.Errorf("%v is older than the minimum of %v", version, policy.GetMinVersion())
+		}
+	}
+
+	if len(policy.GetAllowedVersions()) > 0 {
+		allowed := false
+		for _, allowedVersion := range policy.GetAllowedVersions() {
+			allow, err := parseGoVersion(allowedVersion)
+			if err == nil && parsed.sameRelease(allow) && parsed.compare(allow) >= 0 {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%v is not one of %v", version, policy.GetAllowedVersions())
+		}
+	}
+
+	if policy.GetMatchBuildserver() {
+		buildserver, err := parseGoVersion(buildserverVersion)
+		if err != nil {
+			return fmt.Errorf("Unable to read buildserver version: %v", err)
+		}
+		if !parsed.sameRelease(buildserver) {
+			return fmt.Errorf("%v does not match the buildserver on %v", version, buildserverVersion)
+		}
+	}
+
+	return nil
+}
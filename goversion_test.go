@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+func TestGoVersionCompare(t *testing.T) {
+	var tests = []struct {
+		a, b string
+		res  int
+	}{
+		{"go1.11.6", "go1.11.6", 0},
+		{"go1.11.7", "go1.11.6", 1},
+		{"go1.11", "go1.11.6", -1},
+		{"go1.12", "go1.11.6", 1},
+		{"go1.9.2", "go1.11.6", -1},
+		{"go1.14rc1", "go1.14", -1},
+		{"go1.14beta1", "go1.14rc1", -1},
+		{"go2", "go1.14", 1},
+	}
+
+	for _, test := range tests {
+		a, err := parseGoVersion(test.a)
+		if err != nil {
+			t.Fatalf("Unable to parse %v: %v", test.a, err)
+		}
+		b, err := parseGoVersion(test.b)
+		if err != nil {
+			t.Fatalf("Unable to parse %v: %v", test.b, err)
+		}
+		if a.compare(b) != test.res {
+			t.Errorf("Comparing %v with %v gave %v, expected %v", test.a, test.b, a.compare(b), test.res)
+		}
+	}
+}
+
+func TestGoVersionParseFail(t *testing.T) {
+	if v, err := parseGoVersion("badversion"); err == nil {
+		t.Errorf("Parsed a bad version: %v", v)
+	}
+}
+
+func TestGoVersionPolicy(t *testing.T) {
+	var tests = []struct {
+		policy      *pb.GoPolicy
+		version     string
+		buildserver string
+		pass        bool
+	}{
+		{&pb.GoPolicy{MinVersion: "go1.11.6"}, "go1.11.6", "", true},
+		{&pb.GoPolicy{MinVersion: "go1.11.6"}, "go1.12.1", "", true},
+		{&pb.GoPolicy{MinVersion: "go1.11.6"}, "go1.10", "", false},
+		{&pb.GoPolicy{AllowedVersions: []string{"go1.11.6", "go1.13"}}, "go1.11.9", "", true},
+		{&pb.GoPolicy{AllowedVersions: []string{"go1.11.6", "go1.13"}}, "go1.13.4", "", true},
+		{&pb.GoPolicy{AllowedVersions: []string{"go1.11.6", "go1.13"}}, "go1.12", "", false},
+		{&pb.GoPolicy{AllowedVersions: []string{"go1.11.6"}}, "go1.11.5", "", false},
+		{&pb.GoPolicy{MatchBuildserver: true}, "go1.13.4", "go1.13.1", true},
+		{&pb.GoPolicy{MatchBuildserver: true}, "go1.12.4", "go1.13.1", false},
+		{&pb.GoPolicy{MatchBuildserver: true}, "go1.13.4", "", false},
+		{&pb.GoPolicy{}, "badversion", "", false},
+	}
+
+	s := InitTestServer()
+	for _, test := range tests {
+		s.config.GoPolicy = test.policy
+		err := s.checkGoVersion(test.version, test.buildserver)
+		if (err == nil) != test.pass {
+			t.Errorf("%v against %v (buildserver %v) gave %v", test.version, test.policy, test.buildserver, err)
+		}
+	}
+}
+
+func TestGoVersionMatchBuildserver(t *testing.T) {
+	s := InitTestServer()
+	s.config.GoPolicy = &pb.GoPolicy{MatchBuildserver: true}
+	s.goserver = &testGoserver{reportsNormal: true, goversion: true}
+	s.lookForGoVersion(context.Background())
+	if alertCount(s) != 0 {
+		t.Errorf("Error in alerting: %v", alertCount(s))
+	}
+}
+
+func TestGoVersionResolvesWhenServiceLeaves(t *testing.T) {
+	s := InitTestServer()
+	s.lookForGoVersion(context.Background())
+	if alert, ok := s.alerts.get("look_for_go_version:gobuildslave"); !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Fatalf("Bad version was not raised: %v", alert)
+	}
+
+	s.discover = &testDiscovery{services: []*pbd.RegistryEntry{}}
+	s.lookForGoVersion(context.Background())
+
+	if alert, _ := s.alerts.get("look_for_go_version:gobuildslave"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Bad version was not resolved once the service left: %v", alert)
+	}
+}
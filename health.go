@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+const (
+	// HEARTBEATS is where we store the latest run of each task in keystore
+	HEARTBEATS = "github.com/brotherlogic/alerter/heartbeats/"
+)
+
+// instance names this alerter in the heartbeats
+func (s *Server) instance() string {
+	return fmt.Sprintf("%v:%v", s.Registry.GetIdentifier(), s.Registry.GetPort())
+}
+
+// saveHeartbeat shares the latest run of the task with the other alerters
+func (s *Server) saveHeartbeat(ctx context.Context, name string) error {
+	s.statusMutex.Lock()
+	run, ok := s.taskStatus[name]
+	if !ok {
+		s.statusMutex.Unlock()
+		return nil
+	}
+	heartbeat := &pb.Heartbeat{
+		Task:     name,
+		Instance: s.instance(),
+		FirstRun: run.firstRun.Unix(),
+		LastRun:  run.lastRun.Unix(),
+		Interval: int64(run.interval / time.Second),
+	}
+	if !run.lastSuccess.IsZero() {
+		heartbeat.LastSuccess = run.lastSuccess.Unix()
+	}
+	s.statusMutex.Unlock()
+
+	return s.KSclient.Save(ctx, HEARTBEATS+name, heartbeat)
+}
+
+// readHeartbeat reads the latest run of the task, which is nil if it has never run anywhere
+func (s *Server) readHeartbeat(ctx context.Context, name string) (*pb.Heartbeat, error) {
+	data, _, err := s.KSclient.Read(ctx, HEARTBEATS+name, &pb.Heartbeat{})
+	if err != nil {
+		if code := status.Convert(err).Code(); code == codes.NotFound || code == codes.InvalidArgument {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data.(*pb.Heartbeat), nil
+}
+
+// staleTasks lists the tasks we own that have not succeeded within the configured multiple of
+// their interval. We own a task if we ran it last, so tasks whose lock has moved to another
+// alerter, or that have never run here, are not considered. If the heartbeat can't be read we
+// judge the task on our own record of it.
+func (s *Server) staleTasks(ctx context.Context) []string {
+	s.statusMutex.Lock()
+	runs := make(map[string]taskStatus)
+	for name, run := range s.taskStatus {
+		runs[name] = *run
+	}
+	s.statusMutex.Unlock()
+
+	stale := []string{}
+	for name, run := range runs {
+		since := run.lastSuccess
+		if since.IsZero() {
+			since = run.firstRun
+		}
+
+		interval := run.interval
+		if interval < time.Minute {
+			interval = time.Minute
+		}
+
+		if time.Since(since) <= interval*time.Duration(s.getConfig().GetHealthMultiple()) {
+			continue
+		}
+
+		// Only a task that looks stale here needs the shared heartbeat, and if we can't read
+		// it we go on what we know
+		heartbeat, err := s.readHeartbeat(ctx, name)
+		if err != nil {
+			s.Log(fmt.Sprintf("Unable to read heartbeat for %v: %v", name, err))
+		} else if heartbeat != nil && heartbeat.GetInstance() != s.instance() {
+			continue
+		}
+
+		stale = append(stale, fmt.Sprintf("%v (last success %v)", name, run.lastSuccess))
+	}
+
+	sort.Strings(stale)
+	return stale
+}
+
+// ReportHealth alerts if we're not healthy
+func (s *Server) ReportHealth() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	stale := s.staleTasks(ctx)
+	if len(stale) > 0 {
+		s.Log(fmt.Sprintf("Unhealthy, tasks are stale: %v", stale))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+func TestHealthyWithNoRuns(t *testing.T) {
+	s := InitTestServer()
+	if !s.ReportHealth() {
+		t.Errorf("Server with no runs should be healthy")
+	}
+}
+
+func TestHealthyAfterSuccess(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.runTask(context.Background(), "check_friends")
+	if !s.ReportHealth() {
+		t.Errorf("Server should be healthy: %v", s.staleTasks(context.Background()))
+	}
+}
+
+func TestUnhealthyWhenFailing(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.tasks["failing"] = func(ctx context.Context) (time.Time, error) {
+		return time.Now().Add(time.Minute * 5), fmt.Errorf("Built to fail")
+	}
+	s.runTask(context.Background(), "failing")
+
+	if !s.ReportHealth() {
+		t.Fatalf("Server should be healthy straight after a failure")
+	}
+
+	s.taskStatus["failing"].firstRun = time.Now().Add(-time.Hour)
+	if s.ReportHealth() {
+		t.Errorf("Server with a failing task should be unhealthy")
+	}
+}
+
+func TestUnhealthyWhenStale(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.runTask(context.Background(), "check_friends")
+	s.taskStatus["check_friends"].lastSuccess = time.Now().Add(-time.Hour)
+
+	if s.ReportHealth() {
+		t.Errorf("Server with a stale task should be unhealthy")
+	}
+}
+
+func TestHealthyWhenTaskMoves(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.runTask(context.Background(), "check_friends")
+	s.taskStatus["check_friends"].lastSuccess = time.Now().Add(-time.Hour)
+
+	// Another alerter has since taken the lock and run the task
+	other := InitTestServer()
+	other.setMaster(true)
+	other.GoServer.KSclient = s.GoServer.KSclient
+	other.Registry = &pbd.RegistryEntry{Identifier: "other", Port: 50051}
+	other.runTask(context.Background(), "check_friends")
+
+	if !s.ReportHealth() {
+		t.Errorf("Server should not answer for a task it no longer runs: %v", s.staleTasks(context.Background()))
+	}
+}
+
+func TestUnhealthyWhenHeartbeatUnreadable(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.runTask(context.Background(), "check_friends")
+	s.taskStatus["check_friends"].lastSuccess = time.Now().Add(-time.Hour)
+	s.GoServer.KSclient.Fail = true
+
+	if s.ReportHealth() {
+		t.Errorf("Server with a stale task should be unhealthy even without its heartbeat")
+	}
+}
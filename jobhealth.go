@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+	pbgs "github.com/brotherlogic/gobuildslave/proto"
+)
+
+// recordStart notes the start time reported for the job, returning the restarts seen within the window
+func (s *Server) recordStart(key string, startTime int64, window time.Duration) []int64 {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+
+	if last, ok := s.jobStarts[key]; ok && startTime > 0 && startTime != last {
+		s.jobRestarts[key] = append(s.jobRestarts[key], startTime)
+	}
+	if startTime > 0 {
+		s.jobStarts[key] = startTime
+	}
+
+	restarts := []int64{}
+	for _, restart := range s.jobRestarts[key] {
+		if time.Since(time.Unix(restart, 0)) <= window {
+			restarts = append(restarts, restart)
+		}
+	}
+	s.jobRestarts[key] = restarts
+	return append([]int64{}, restarts...)
+}
+
+// stuckSince records that the job is not running, returning when that began
+func (s *Server) stuckSince(key string) time.Time {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	if _, ok := s.jobStuck[key]; !ok {
+		s.jobStuck[key] = time.Now()
+	}
+	return s.jobStuck[key]
+}
+
+func (s *Server) clearStuck(key string) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	delete(s.jobStuck, key)
+}
+
+// pruneJobs forgets the starts, restarts and stuck times of jobs that were not seen in this run
+func (s *Server) pruneJobs(seen map[string]bool) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	for key := range s.jobStarts {
+		if !seen[key] {
+			delete(s.jobStarts, key)
+		}
+	}
+	for key := range s.jobRestarts {
+		if !seen[key] {
+			delete(s.jobRestarts, key)
+		}
+	}
+	pruneTimes(s.jobStuck, seen)
+}
+
+// checkJob looks for a job on a slave that keeps restarting or will not run
+func (s *Server) checkJob(ctx context.Context, service *pbd.RegistryEntry, job *pbgs.JobAssignment) {
+	subject := service.Identifier + job.GetJob().GetName()
+	labels := map[string]string{"service": job.GetJob().GetName(), "identifier": service.Identifier}
+
+	window := time.Duration(s.getConfig().GetCrashLoopWindow()) * time.Second
+	restarts := s.recordStart(subject, job.GetStartTime(), window)
+	if int32(len(restarts)) >= s.getConfig().GetCrashLoopRestarts() {
+		s.alerts.fire(ctx, &pb.Alert{Check: "crash_loop", Subject: subject, Labels: labels, Severity: pb.Severity_CRITICAL, Title: "Crash Loop",
+			Body: fmt.Sprintf("%v on %v has restarted %v times in the last %v (%.1f an hour)", job.GetJob().GetName(), service.Identifier, len(restarts), window, float64(len(restarts))/window.Hours())})
+	} else {
+		s.alerts.pass(ctx, "crash_loop", subject)
+	}
+
+	if job.GetState() != pbgs.State_RUNNING {
+		since := s.stuckSince(subject)
+		if time.Since(since) > time.Duration(s.getConfig().GetStuckJobGracePeriod())*time.Second {
+			s.alerts.fire(ctx, &pb.Alert{Check: "stuck_job", Subject: subject, Labels: labels, Severity: pb.Severity_WARNING, Title: "Job Stuck",
+				Body: fmt.Sprintf("%v on %v has been %v since %v", job.GetJob().GetName(), service.Identifier, job.GetState(), since.Format(time.RFC822))})
+		}
+	} else {
+		s.clearStuck(subject)
+		s.alerts.pass(ctx, "stuck_job", subject)
+	}
+}
+
+// lookForCrashLoops samples the jobs on every slave. Each sample can only see one restart of
+// a job, so we sample far more often than the crash loop window.
+func (s *Server) lookForCrashLoops(ctx context.Context) (time.Time, error) {
+	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
+	if err != nil {
+		return time.Now().Add(time.Minute), err
+	}
+
+	seen := make(map[string]bool)
+	for _, service := range serv.GetServices().GetServices() {
+		if service.Name == "gobuildslave" {
+			jobs, err := s.gobuildSlave.ListJobs(ctx, service, &pbgs.ListRequest{})
+			if err == nil {
+				s.recordExamined(ctx, 1, len(jobs.Jobs))
+				for _, job := range jobs.Jobs {
+					seen[service.Identifier+job.GetJob().GetName()] = true
+					s.checkJob(ctx, service, job)
+				}
+			}
+		}
+	}
+	s.alerts.passUnseen(ctx, "crash_loop", seen)
+	s.alerts.passUnseen(ctx, "stuck_job", seen)
+	s.pruneJobs(seen)
+
+	return time.Now().Add(time.Minute), nil
+}
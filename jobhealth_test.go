@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbgbs "github.com/brotherlogic/gobuildslave/proto"
+)
+
+func TestCrashLoop(t *testing.T) {
+	s := InitTestServer()
+	slave := &testGobuildslave{}
+	s.gobuildSlave = slave
+
+	for i := 0; i < 4; i++ {
+		slave.jobs = []*pbgbs.JobAssignment{&pbgbs.JobAssignment{Job: &pbgbs.Job{Name: "madeup"}, State: pbgbs.State_RUNNING, StartTime: time.Now().Unix() - int64(10-i)}}
+		s.lookForCrashLoops(context.Background())
+	}
+
+	alert, ok := s.alerts.get("crash_loop:madeup")
+	if !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Errorf("Crash loop was not raised: %v", alert)
+	}
+}
+
+func TestSteadyJobIsNotCrashLooping(t *testing.T) {
+	s := InitTestServer()
+	s.gobuildSlave = &testGobuildslave{jobs: []*pbgbs.JobAssignment{&pbgbs.JobAssignment{Job: &pbgbs.Job{Name: "madeup"}, State: pbgbs.State_RUNNING, StartTime: time.Now().Unix()}}}
+
+	for i := 0; i < 4; i++ {
+		s.lookForCrashLoops(context.Background())
+	}
+
+	if alert, ok := s.alerts.get("crash_loop:madeup"); ok {
+		t.Errorf("Steady job was raised: %v", alert)
+	}
+}
+
+func TestStuckJob(t *testing.T) {
+	s := InitTestServer()
+	s.gobuildSlave = &testGobuildslave{jobs: []*pbgbs.JobAssignment{&pbgbs.JobAssignment{Job: &pbgbs.Job{Name: "madeup"}, State: pbgbs.State_BUILDING}}}
+	s.jobStuck["madeup"] = time.Now().Add(-time.Hour)
+
+	s.lookForCrashLoops(context.Background())
+
+	alert, ok := s.alerts.get("stuck_job:madeup")
+	if !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Fatalf("Stuck job was not raised: %v", alert)
+	}
+
+	s.gobuildSlave = &testGobuildslave{jobs: []*pbgbs.JobAssignment{&pbgbs.JobAssignment{Job: &pbgbs.Job{Name: "madeup"}, State: pbgbs.State_RUNNING}}}
+	s.lookForCrashLoops(context.Background())
+
+	if alert, _ = s.alerts.get("stuck_job:madeup"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Stuck job was not resolved: %v", alert)
+	}
+}
+
+func TestCrashLoopSampling(t *testing.T) {
+	s := InitTestServer()
+	next, _ := s.lookForCrashLoops(context.Background())
+
+	// We need to see every restart in the window to reach the limit
+	if time.Until(next)*time.Duration(s.config.GetCrashLoopRestarts()) >= time.Duration(s.config.GetCrashLoopWindow())*time.Second {
+		t.Errorf("Sampling every %v cannot see %v restarts in %v seconds", time.Until(next), s.config.GetCrashLoopRestarts(), s.config.GetCrashLoopWindow())
+	}
+}
+
+func TestJobAlertsResolveWhenJobLeaves(t *testing.T) {
+	s := InitTestServer()
+	s.gobuildSlave = &testGobuildslave{jobs: []*pbgbs.JobAssignment{&pbgbs.JobAssignment{Job: &pbgbs.Job{Name: "madeup"}, State: pbgbs.State_BUILDING}}}
+	s.jobStuck["madeup"] = time.Now().Add(-time.Hour)
+	s.lookForCrashLoops(context.Background())
+
+	s.gobuildSlave = &testGobuildslave{jobs: []*pbgbs.JobAssignment{}}
+	s.lookForCrashLoops(context.Background())
+
+	if alert, _ := s.alerts.get("stuck_job:madeup"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Stuck job was not resolved once it left: %v", alert)
+	}
+	if len(s.jobStuck) != 0 || len(s.jobStarts) != 0 {
+		t.Errorf("Job times were kept: %v, %v", s.jobStuck, s.jobStarts)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+// masterSince records a master problem for the service, returning when it began
+func (s *Server) masterSince(name string) time.Time {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	if _, ok := s.masterProblems[name]; !ok {
+		s.masterProblems[name] = time.Now()
+	}
+	return s.masterProblems[name]
+}
+
+// hasMastered records whether the service has a master now, returning whether it has ever had one
+func (s *Server) hasMastered(name string, master bool) bool {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	if master {
+		s.mastered[name] = true
+	}
+	return s.mastered[name]
+}
+
+func (s *Server) clearMaster(name string) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	delete(s.masterProblems, name)
+}
+
+// describeEntries lists the identifier and address of each entry, sorted
+func describeEntries(entries []*pbd.RegistryEntry) string {
+	described := []string{}
+	for _, entry := range entries {
+		described = append(described, fmt.Sprintf("%v (%v:%v)", entry.Identifier, entry.Ip, entry.Port))
+	}
+	sort.Strings(described)
+	return strings.Join(described, ", ")
+}
+
+// lookForMasters checks that every service in the registry has exactly one master. Services
+// that have never had a master don't elect one, so they are left alone.
+func (s *Server) lookForMasters(ctx context.Context) (time.Time, error) {
+	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+
+	exempt := make(map[string]bool)
+	for _, name := range s.getConfig().GetMasterExempt() {
+		exempt[name] = true
+	}
+
+	services := make(map[string][]*pbd.RegistryEntry)
+	masters := make(map[string][]*pbd.RegistryEntry)
+	for _, service := range serv.GetServices().GetServices() {
+		services[service.Name] = append(services[service.Name], service)
+		if service.Master {
+			masters[service.Name] = append(masters[service.Name], service)
+		}
+	}
+	s.recordExamined(ctx, len(services), 0)
+
+	grace := time.Duration(s.getConfig().GetMasterGracePeriod()) * time.Second
+	seen := make(map[string]bool)
+	for name, entries := range services {
+		mastered := s.hasMastered(name, len(masters[name]) > 0)
+		if exempt[name] || !mastered || len(masters[name]) == 1 {
+			s.clearMaster(name)
+			continue
+		}
+
+		since := s.masterSince(name)
+		if time.Since(since) <= grace {
+			continue
+		}
+
+		seen[name] = true
+		if len(masters[name]) == 0 {
+			s.alerts.fire(ctx, &pb.Alert{Check: "single_master", Subject: name, Labels: map[string]string{"service": name}, Severity: pb.Severity_CRITICAL, Title: "No Master",
+				Body: fmt.Sprintf("%v has had no master since %v across %v", name, since.Format(time.RFC822), describeEntries(entries))})
+		} else {
+			s.alerts.fire(ctx, &pb.Alert{Check: "single_master", Subject: name, Labels: map[string]string{"service": name}, Severity: pb.Severity_CRITICAL, Title: "Split Master",
+				Body: fmt.Sprintf("%v has had %v masters since %v: %v", name, len(masters[name]), since.Format(time.RFC822), describeEntries(masters[name]))})
+		}
+	}
+
+	// Services that have left the registry no longer have a master problem
+	s.stateMutex.Lock()
+	for name := range s.masterProblems {
+		if _, ok := services[name]; !ok {
+			delete(s.masterProblems, name)
+		}
+	}
+	s.stateMutex.Unlock()
+	s.alerts.passUnseen(ctx, "single_master", seen)
+
+	return time.Now().Add(time.Minute * 5), nil
+}
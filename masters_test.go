@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+func TestSplitMaster(t *testing.T) {
+	s := InitTestServer()
+	s.discover = &testDiscovery{services: []*pbd.RegistryEntry{
+		&pbd.RegistryEntry{Name: "recordcollection", Identifier: "alpha", Ip: "10.0.0.1", Port: 50051, Master: true},
+		&pbd.RegistryEntry{Name: "recordcollection", Identifier: "beta", Ip: "10.0.0.2", Port: 50052, Master: true},
+	}}
+	s.masterProblems["recordcollection"] = time.Now().Add(-time.Hour)
+
+	s.lookForMasters(context.Background())
+
+	alert, ok := s.alerts.get("single_master:recordcollection")
+	if !ok || alert.GetTitle() != "Split Master" {
+		t.Errorf("Split master was not raised: %v", alert)
+	}
+}
+
+func TestMasterWithinGrace(t *testing.T) {
+	s := InitTestServer()
+	s.discover = &testDiscovery{services: []*pbd.RegistryEntry{&pbd.RegistryEntry{Name: "recordcollection", Identifier: "alpha"}}}
+	s.mastered["recordcollection"] = true
+
+	s.lookForMasters(context.Background())
+
+	if alert, ok := s.alerts.get("single_master:recordcollection"); ok {
+		t.Errorf("Alerted within the grace period: %v", alert)
+	}
+	if _, ok := s.masterProblems["recordcollection"]; !ok {
+		t.Errorf("Problem was not recorded")
+	}
+}
+
+func TestNoMasterResolves(t *testing.T) {
+	s := InitTestServer()
+	d := &testDiscovery{services: []*pbd.RegistryEntry{&pbd.RegistryEntry{Name: "recordcollection", Identifier: "alpha"}}}
+	s.discover = d
+	s.mastered["recordcollection"] = true
+	s.masterProblems["recordcollection"] = time.Now().Add(-time.Hour)
+
+	s.lookForMasters(context.Background())
+	alert, ok := s.alerts.get("single_master:recordcollection")
+	if !ok || alert.GetTitle() != "No Master" {
+		t.Fatalf("No master was not raised: %v", alert)
+	}
+
+	d.services[0].Master = true
+	s.lookForMasters(context.Background())
+	if alert, _ = s.alerts.get("single_master:recordcollection"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Alert was not resolved: %v", alert)
+	}
+}
+
+func TestNeverMasteredIgnored(t *testing.T) {
+	s := InitTestServer()
+	s.masterProblems["gobuildslave"] = time.Now().Add(-time.Hour)
+
+	s.lookForMasters(context.Background())
+
+	if alert, ok := s.alerts.get("single_master:gobuildslave"); ok {
+		t.Errorf("Alerted on a service that has never had a master: %v", alert)
+	}
+}
+
+func TestMasterExempt(t *testing.T) {
+	s := InitTestServer()
+	s.config.MasterExempt = []string{"gobuildslave"}
+	s.mastered["gobuildslave"] = true
+	s.masterProblems["gobuildslave"] = time.Now().Add(-time.Hour)
+
+	s.lookForMasters(context.Background())
+
+	if alert, ok := s.alerts.get("single_master:gobuildslave"); ok {
+		t.Errorf("Alerted on an exempt service: %v", alert)
+	}
+}
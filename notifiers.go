@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+const (
+	// How long we give an SMTP server to take an email
+	emailTimeout = time.Second * 30
+)
+
+// Notifier sends alerts, both firing and resolved, out of the alerter
+type Notifier interface {
+	Notify(ctx context.Context, alert *pb.Alert) error
+}
+
+func describe(alert *pb.Alert) (string, string) {
+	if alert.GetState() == pb.Alert_RESOLVED {
+		return fmt.Sprintf("Resolved: %v", alert.GetTitle()), fmt.Sprintf("%v has cleared after %v", alert.GetKey(), time.Unix(alert.GetResolvedTime(), 0).Sub(time.Unix(alert.GetFirstRaised(), 0)))
+	}
+	return alert.GetTitle(), alert.GetBody()
+}
+
+type issueNotifier struct {
+	tracker IssueTracker
+	record  func(key string, number int32)
+}
+
+// Notify opens an issue for a firing alert, and closes it with a note once the alert resolves.
+// Repeats of an alert that already has an open issue leave that issue as it is.
+func (i *issueNotifier) Notify(ctx context.Context, alert *pb.Alert) error {
+	if alert.GetState() == pb.Alert_RESOLVED {
+		if alert.GetIssueNumber() == 0 {
+			return nil
+		}
+		_, note := describe(alert)
+		err := i.tracker.CloseIssue(ctx, alert.GetIssueNumber(), note)
+		if err == nil {
+			i.record(alert.GetKey(), 0)
+		}
+		return err
+	}
+
+	if alert.GetIssueNumber() != 0 {
+		return nil
+	}
+
+	number, err := i.tracker.AddIssue(ctx, alert.GetTitle(), alert.GetBody())
+	if err != nil {
+		return err
+	}
+	i.record(alert.GetKey(), number)
+	return nil
+}
+
+type emailNotifier struct {
+	server string
+	from   string
+	to     []string
+}
+
+// Notify emails the alert to the recipients, giving up once the context is done or the
+// server has taken too long
+func (e *emailNotifier) Notify(ctx context.Context, alert *pb.Alert) error {
+	title, body := describe(alert)
+	message := fmt.Sprintf("From: %v\r\nTo: %v\r\nSubject: [%v] %v\r\n\r\n%v\r\n", e.from, strings.Join(e.to, ", "), severity(alert), title, body)
+
+	dialer := &net.Dialer{Timeout: emailTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(emailTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+
+	// Cancelling the context cuts off the conversation with the server
+	done := make(chan bool)
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	host, _, err := net.SplitHostPort(e.server)
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(e.from); err != nil {
+		return err
+	}
+	for _, to := range e.to {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write([]byte(message)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify posts the alert as JSON to the webhook
+func (w *webhookNotifier) Notify(ctx context.Context, alert *pb.Alert) error {
+	marshaler := &jsonpb.Marshaler{}
+	data, err := marshaler.MarshalToString(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", w.url, bytes.NewBufferString(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook %v returned %v", w.url, resp.Status)
+	}
+	return nil
+}
+
+type fileNotifier struct {
+	path string
+}
+
+// Notify writes the alert as a single line to the file, or to stdout when there is no path
+func (f *fileNotifier) Notify(ctx context.Context, alert *pb.Alert) error {
+	var out io.Writer = os.Stdout
+	if len(f.path) > 0 {
+		file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	title, body := describe(alert)
+	_, err := fmt.Fprintf(out, "%v [%v] %v: %v\n", time.Now().Format(time.RFC3339), severity(alert), title, body)
+	return err
+}
+
+// notifier builds the named notifier from the config, "issue" is always available
+func (s *Server) notifier(name string) (Notifier, error) {
+	if name == "issue" {
+		return &issueNotifier{tracker: s.issues, record: s.alerts.setIssue}, nil
+	}
+
+	for _, config := range s.getConfig().GetNotifiers() {
+		if config.GetName() == name {
+			switch config.GetType() {
+			case pb.Notifier_ISSUE:
+				return &issueNotifier{tracker: s.issues, record: s.alerts.setIssue}, nil
+			case pb.Notifier_EMAIL:
+				return &emailNotifier{server: config.GetAddress(), from: config.GetFrom(), to: config.GetTo()}, nil
+			case pb.Notifier_WEBHOOK:
+				return &webhookNotifier{url: config.GetAddress(), client: &http.Client{Timeout: time.Second * 30}}, nil
+			case pb.Notifier_FILE:
+				return &fileNotifier{path: config.GetAddress()}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("Unable to locate notifier %v", name)
+}
+
+// hasIssueNotifier checks if any of the named notifiers raises issues
+func (s *Server) hasIssueNotifier(notifiers []string) bool {
+	for _, name := range notifiers {
+		if notifier, err := s.notifier(name); err == nil {
+			if _, ok := notifier.(*issueNotifier); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// notify fans the alert out to each of the notifiers
+func (s *Server) notify(ctx context.Context, alert *pb.Alert, notifiers []string) {
+	if len(notifiers) == 0 {
+		notifiers = []string{"issue"}
+	}
+
+	for _, name := range notifiers {
+		notifier, err := s.notifier(name)
+		if err == nil {
+			err = notifier.Notify(ctx, alert)
+		}
+		if err != nil {
+			s.Log(fmt.Sprintf("Unable to notify %v of %v: %v", name, alert.GetKey(), err))
+		}
+	}
+}
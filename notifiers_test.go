@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+// fakeSMTP is a local stand-in for an SMTP server, passing on each message it receives
+func fakeSMTP(t *testing.T) (string, chan string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+
+	messages := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, messages)
+		}
+	}()
+
+	return lis.Addr().String(), messages
+}
+
+func serveSMTP(conn net.Conn, messages chan string) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	fmt.Fprintf(conn, "220 localhost ESMTP\r\n")
+
+	data := false
+	message := ""
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		if data {
+			if line == ".\r\n" {
+				data = false
+				messages <- message
+				fmt.Fprintf(conn, "250 OK\r\n")
+			} else {
+				message += line
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+			fmt.Fprintf(conn, "250 localhost\r\n")
+		case strings.HasPrefix(line, "DATA"):
+			data = true
+			fmt.Fprintf(conn, "354 Go ahead\r\n")
+		case strings.HasPrefix(line, "QUIT"):
+			fmt.Fprintf(conn, "221 Bye\r\n")
+			return
+		default:
+			fmt.Fprintf(conn, "250 OK\r\n")
+		}
+	}
+}
+
+// silentSMTP is a local stand-in for an SMTP server that accepts connections but never answers
+func silentSMTP(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+
+	go func() {
+		// Hold every connection open without saying anything
+		conns := []net.Conn{}
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	return lis.Addr().String()
+}
+
+// fakeWebhook records the bodies posted to it
+func fakeWebhook() (*httptest.Server, chan string) {
+	bodies := make(chan string, 10)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies <- string(body)
+	})), bodies
+}
+
+func TestEmailNotifierGivesUp(t *testing.T) {
+	notifier := &emailNotifier{server: silentSMTP(t), from: "alerter@localhost", to: []string{"brotherlogic@localhost"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond * 100)
+		cancel()
+	}()
+
+	result := make(chan error)
+	go func() {
+		result <- notifier.Notify(ctx, &pb.Alert{Title: "Problem", Body: "Broken"})
+	}()
+
+	select {
+	case err := <-result:
+		if err == nil {
+			t.Errorf("Silent server took the email")
+		}
+	case <-time.After(time.Second * 5):
+		t.Errorf("Email was still being sent after the context was cancelled")
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	addr, messages := fakeSMTP(t)
+	notifier := &emailNotifier{server: addr, from: "alerter@localhost", to: []string{"brotherlogic@localhost"}}
+
+	err := notifier.Notify(context.Background(), &pb.Alert{Title: "Problem", Body: "Broken", Severity: pb.Severity_CRITICAL})
+	if err != nil {
+		t.Fatalf("Unable to send email: %v", err)
+	}
+
+	select {
+	case message := <-messages:
+		if !strings.Contains(message, "Subject: [CRITICAL] Problem") || !strings.Contains(message, "Broken") {
+			t.Errorf("Bad email: %v", message)
+		}
+	case <-time.After(time.Second * 5):
+		t.Errorf("No email was received")
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	server, bodies := fakeWebhook()
+	defer server.Close()
+	notifier := &webhookNotifier{url: server.URL, client: server.Client()}
+
+	err := notifier.Notify(context.Background(), &pb.Alert{Key: "check", Title: "Problem", Body: "Broken"})
+	if err != nil {
+		t.Fatalf("Unable to call webhook: %v", err)
+	}
+
+	body := <-bodies
+	if !strings.Contains(body, "\"title\":\"Problem\"") {
+		t.Errorf("Bad webhook body: %v", body)
+	}
+}
+
+func TestWebhookNotifierFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	notifier := &webhookNotifier{url: server.URL, client: server.Client()}
+
+	err := notifier.Notify(context.Background(), &pb.Alert{Key: "check", Title: "Problem", Body: "Broken"})
+	if err == nil {
+		t.Errorf("Failing webhook did not error")
+	}
+}
+
+func TestFileNotifier(t *testing.T) {
+	file, err := ioutil.TempFile("", "alerter")
+	if err != nil {
+		t.Fatalf("Unable to create file: %v", err)
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	notifier := &fileNotifier{path: file.Name()}
+	notifier.Notify(context.Background(), &pb.Alert{Title: "Problem", Body: "Broken"})
+	notifier.Notify(context.Background(), &pb.Alert{Title: "Problem", State: pb.Alert_RESOLVED})
+
+	data, _ := ioutil.ReadFile(file.Name())
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "Resolved: Problem") {
+		t.Errorf("Bad file output: %v", lines)
+	}
+}
+
+func TestRouteFansOut(t *testing.T) {
+	addr, messages := fakeSMTP(t)
+	server, bodies := fakeWebhook()
+	defer server.Close()
+
+	s := InitTestServer()
+	s.config.Notifiers = []*pb.Notifier{
+		&pb.Notifier{Name: "email", Type: pb.Notifier_EMAIL, Address: addr, From: "alerter@localhost", To: []string{"brotherlogic@localhost"}},
+		&pb.Notifier{Name: "hook", Type: pb.Notifier_WEBHOOK, Address: server.URL},
+	}
+	s.config.Routes = []*pb.Route{&pb.Route{Severity: pb.Severity_CRITICAL, Action: pb.Route_NOTIFY, Notifiers: []string{"email", "hook", "missing"}}}
+
+	s.alerts.fire(context.Background(), &pb.Alert{Check: "check", Title: "Problem", Body: "Broken", Severity: pb.Severity_CRITICAL})
+
+	select {
+	case <-messages:
+	case <-time.After(time.Second * 5):
+		t.Errorf("No email was received")
+	}
+	select {
+	case <-bodies:
+	case <-time.After(time.Second * 5):
+		t.Errorf("No webhook call was received")
+	}
+}
@@ -0,0 +1,2695 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: alerter.proto
+
+package proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Severity int32
+
+const (
+	Severity_UNSET    Severity = 0
+	Severity_INFO     Severity = 1
+	Severity_WARNING  Severity = 2
+	Severity_CRITICAL Severity = 3
+)
+
+var Severity_name = map[int32]string{
+	0: "UNSET",
+	1: "INFO",
+	2: "WARNING",
+	3: "CRITICAL",
+}
+
+var Severity_value = map[string]int32{
+	"UNSET":    0,
+	"INFO":     1,
+	"WARNING":  2,
+	"CRITICAL": 3,
+}
+
+func (x Severity) String() string {
+	return proto.EnumName(Severity_name, int32(x))
+}
+
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{0}
+}
+
+type Notifier_Type int32
+
+const (
+	Notifier_ISSUE   Notifier_Type = 0
+	Notifier_EMAIL   Notifier_Type = 1
+	Notifier_WEBHOOK Notifier_Type = 2
+	Notifier_FILE    Notifier_Type = 3
+)
+
+var Notifier_Type_name = map[int32]string{
+	0: "ISSUE",
+	1: "EMAIL",
+	2: "WEBHOOK",
+	3: "FILE",
+}
+
+var Notifier_Type_value = map[string]int32{
+	"ISSUE":   0,
+	"EMAIL":   1,
+	"WEBHOOK": 2,
+	"FILE":    3,
+}
+
+func (x Notifier_Type) String() string {
+	return proto.EnumName(Notifier_Type_name, int32(x))
+}
+
+func (Notifier_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{0, 0}
+}
+
+type Route_Action int32
+
+const (
+	// Sends the alert to the route's notifiers, raising an issue when it has none
+	Route_NOTIFY Route_Action = 0
+	Route_DIGEST Route_Action = 1
+	Route_LOG    Route_Action = 2
+)
+
+var Route_Action_name = map[int32]string{
+	0: "NOTIFY",
+	1: "DIGEST",
+	2: "LOG",
+}
+
+var Route_Action_value = map[string]int32{
+	"NOTIFY": 0,
+	"DIGEST": 1,
+	"LOG":    2,
+}
+
+func (x Route_Action) String() string {
+	return proto.EnumName(Route_Action_name, int32(x))
+}
+
+func (Route_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{1, 0}
+}
+
+type Rule_Field int32
+
+const (
+	Rule_VALUE    Rule_Field = 0
+	Rule_FRACTION Rule_Field = 1
+	Rule_TEXT     Rule_Field = 2
+)
+
+var Rule_Field_name = map[int32]string{
+	0: "VALUE",
+	1: "FRACTION",
+	2: "TEXT",
+}
+
+var Rule_Field_value = map[string]int32{
+	"VALUE":    0,
+	"FRACTION": 1,
+	"TEXT":     2,
+}
+
+func (x Rule_Field) String() string {
+	return proto.EnumName(Rule_Field_name, int32(x))
+}
+
+func (Rule_Field) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{3, 0}
+}
+
+type Rule_Comparator int32
+
+const (
+	Rule_GREATER_THAN Rule_Comparator = 0
+	Rule_LESS_THAN    Rule_Comparator = 1
+	Rule_EQUAL        Rule_Comparator = 2
+	Rule_NOT_EQUAL    Rule_Comparator = 3
+)
+
+var Rule_Comparator_name = map[int32]string{
+	0: "GREATER_THAN",
+	1: "LESS_THAN",
+	2: "EQUAL",
+	3: "NOT_EQUAL",
+}
+
+var Rule_Comparator_value = map[string]int32{
+	"GREATER_THAN": 0,
+	"LESS_THAN":    1,
+	"EQUAL":        2,
+	"NOT_EQUAL":    3,
+}
+
+func (x Rule_Comparator) String() string {
+	return proto.EnumName(Rule_Comparator_name, int32(x))
+}
+
+func (Rule_Comparator) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{3, 1}
+}
+
+type Alert_State int32
+
+const (
+	Alert_UNKNOWN      Alert_State = 0
+	Alert_FIRING       Alert_State = 1
+	Alert_ACKNOWLEDGED Alert_State = 2
+	Alert_RESOLVED     Alert_State = 3
+)
+
+var Alert_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "FIRING",
+	2: "ACKNOWLEDGED",
+	3: "RESOLVED",
+}
+
+var Alert_State_value = map[string]int32{
+	"UNKNOWN":      0,
+	"FIRING":       1,
+	"ACKNOWLEDGED": 2,
+	"RESOLVED":     3,
+}
+
+func (x Alert_State) String() string {
+	return proto.EnumName(Alert_State_name, int32(x))
+}
+
+func (Alert_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{8, 0}
+}
+
+type GetFriendGraphRequest_Format int32
+
+const (
+	GetFriendGraphRequest_JSON GetFriendGraphRequest_Format = 0
+	GetFriendGraphRequest_DOT  GetFriendGraphRequest_Format = 1
+)
+
+var GetFriendGraphRequest_Format_name = map[int32]string{
+	0: "JSON",
+	1: "DOT",
+}
+
+var GetFriendGraphRequest_Format_value = map[string]int32{
+	"JSON": 0,
+	"DOT":  1,
+}
+
+func (x GetFriendGraphRequest_Format) String() string {
+	return proto.EnumName(GetFriendGraphRequest_Format_name, int32(x))
+}
+
+func (GetFriendGraphRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{28, 0}
+}
+
+type Notifier struct {
+	Name string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type Notifier_Type `protobuf:"varint,2,opt,name=type,proto3,enum=alerter.Notifier_Type" json:"type,omitempty"`
+	// The SMTP server, the webhook URL or the file path; an empty path writes to stdout
+	Address string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// The sender and recipients of emails
+	From                 string   `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To                   []string `protobuf:"bytes,5,rep,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Notifier) Reset()         { *m = Notifier{} }
+func (m *Notifier) String() string { return proto.CompactTextString(m) }
+func (*Notifier) ProtoMessage()    {}
+func (*Notifier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{0}
+}
+
+func (m *Notifier) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Notifier.Unmarshal(m, b)
+}
+func (m *Notifier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Notifier.Marshal(b, m, deterministic)
+}
+func (m *Notifier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Notifier.Merge(m, src)
+}
+func (m *Notifier) XXX_Size() int {
+	return xxx_messageInfo_Notifier.Size(m)
+}
+func (m *Notifier) XXX_DiscardUnknown() {
+	xxx_messageInfo_Notifier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Notifier proto.InternalMessageInfo
+
+func (m *Notifier) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Notifier) GetType() Notifier_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Notifier_ISSUE
+}
+
+func (m *Notifier) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Notifier) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *Notifier) GetTo() []string {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+type Route struct {
+	// The check this route applies to, empty matches every check
+	Check string `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	// The severity this route applies to, unset matches every severity
+	Severity Severity     `protobuf:"varint,2,opt,name=severity,proto3,enum=alerter.Severity" json:"severity,omitempty"`
+	Action   Route_Action `protobuf:"varint,3,opt,name=action,proto3,enum=alerter.Route_Action" json:"action,omitempty"`
+	// The names of the notifiers this route fans out to
+	Notifiers            []string `protobuf:"bytes,4,rep,name=notifiers,proto3" json:"notifiers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Route) Reset()         { *m = Route{} }
+func (m *Route) String() string { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()    {}
+func (*Route) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{1}
+}
+
+func (m *Route) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Route.Unmarshal(m, b)
+}
+func (m *Route) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Route.Marshal(b, m, deterministic)
+}
+func (m *Route) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Route.Merge(m, src)
+}
+func (m *Route) XXX_Size() int {
+	return xxx_messageInfo_Route.Size(m)
+}
+func (m *Route) XXX_DiscardUnknown() {
+	xxx_messageInfo_Route.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Route proto.InternalMessageInfo
+
+func (m *Route) GetCheck() string {
+	if m != nil {
+		return m.Check
+	}
+	return ""
+}
+
+func (m *Route) GetSeverity() Severity {
+	if m != nil {
+		return m.Severity
+	}
+	return Severity_UNSET
+}
+
+func (m *Route) GetAction() Route_Action {
+	if m != nil {
+		return m.Action
+	}
+	return Route_NOTIFY
+}
+
+func (m *Route) GetNotifiers() []string {
+	if m != nil {
+		return m.Notifiers
+	}
+	return nil
+}
+
+type GoPolicy struct {
+	// Services must run at least this go version
+	MinVersion string `protobuf:"bytes,1,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	// When set, services must run one of these versions or a later patch release of them
+	AllowedVersions []string `protobuf:"bytes,2,rep,name=allowed_versions,json=allowedVersions,proto3" json:"allowed_versions,omitempty"`
+	// Services must run the same go release as the buildserver
+	MatchBuildserver     bool     `protobuf:"varint,3,opt,name=match_buildserver,json=matchBuildserver,proto3" json:"match_buildserver,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GoPolicy) Reset()         { *m = GoPolicy{} }
+func (m *GoPolicy) String() string { return proto.CompactTextString(m) }
+func (*GoPolicy) ProtoMessage()    {}
+func (*GoPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{2}
+}
+
+func (m *GoPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GoPolicy.Unmarshal(m, b)
+}
+func (m *GoPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GoPolicy.Marshal(b, m, deterministic)
+}
+func (m *GoPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GoPolicy.Merge(m, src)
+}
+func (m *GoPolicy) XXX_Size() int {
+	return xxx_messageInfo_GoPolicy.Size(m)
+}
+func (m *GoPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_GoPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GoPolicy proto.InternalMessageInfo
+
+func (m *GoPolicy) GetMinVersion() string {
+	if m != nil {
+		return m.MinVersion
+	}
+	return ""
+}
+
+func (m *GoPolicy) GetAllowedVersions() []string {
+	if m != nil {
+		return m.AllowedVersions
+	}
+	return nil
+}
+
+func (m *GoPolicy) GetMatchBuildserver() bool {
+	if m != nil {
+		return m.MatchBuildserver
+	}
+	return false
+}
+
+type Rule struct {
+	// The name of the rule, used as the check name on the alerts it raises
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The name of the services this rule applies to, empty selects every service
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// The state key this rule reads
+	Key        string          `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Field      Rule_Field      `protobuf:"varint,4,opt,name=field,proto3,enum=alerter.Rule_Field" json:"field,omitempty"`
+	Comparator Rule_Comparator `protobuf:"varint,5,opt,name=comparator,proto3,enum=alerter.Rule_Comparator" json:"comparator,omitempty"`
+	// The threshold used against the value and fraction fields
+	Threshold float64 `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// The threshold used against the text field
+	TextThreshold string `protobuf:"bytes,7,opt,name=text_threshold,json=textThreshold,proto3" json:"text_threshold,omitempty"`
+	// How long, in seconds, the rule must be broken before we alert
+	ForDuration          int64    `protobuf:"varint,8,opt,name=for_duration,json=forDuration,proto3" json:"for_duration,omitempty"`
+	Severity             Severity `protobuf:"varint,9,opt,name=severity,proto3,enum=alerter.Severity" json:"severity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Rule) Reset()         { *m = Rule{} }
+func (m *Rule) String() string { return proto.CompactTextString(m) }
+func (*Rule) ProtoMessage()    {}
+func (*Rule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{3}
+}
+
+func (m *Rule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rule.Unmarshal(m, b)
+}
+func (m *Rule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rule.Marshal(b, m, deterministic)
+}
+func (m *Rule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rule.Merge(m, src)
+}
+func (m *Rule) XXX_Size() int {
+	return xxx_messageInfo_Rule.Size(m)
+}
+func (m *Rule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rule proto.InternalMessageInfo
+
+func (m *Rule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Rule) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Rule) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Rule) GetField() Rule_Field {
+	if m != nil {
+		return m.Field
+	}
+	return Rule_VALUE
+}
+
+func (m *Rule) GetComparator() Rule_Comparator {
+	if m != nil {
+		return m.Comparator
+	}
+	return Rule_GREATER_THAN
+}
+
+func (m *Rule) GetThreshold() float64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *Rule) GetTextThreshold() string {
+	if m != nil {
+		return m.TextThreshold
+	}
+	return ""
+}
+
+func (m *Rule) GetForDuration() int64 {
+	if m != nil {
+		return m.ForDuration
+	}
+	return 0
+}
+
+func (m *Rule) GetSeverity() Severity {
+	if m != nil {
+		return m.Severity
+	}
+	return Severity_UNSET
+}
+
+type Config struct {
+	// How long, in seconds, a job can run a stale version before we alert
+	VersionGracePeriod int64 `protobuf:"varint,1,opt,name=version_grace_period,json=versionGracePeriod,proto3" json:"version_grace_period,omitempty"`
+	// Per-job overrides of the version grace period, keyed by job name
+	JobGracePeriods map[string]int64 `protobuf:"bytes,2,rep,name=job_grace_periods,json=jobGracePeriods,proto3" json:"job_grace_periods,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	GoPolicy        *GoPolicy        `protobuf:"bytes,3,opt,name=go_policy,json=goPolicy,proto3" json:"go_policy,omitempty"`
+	Rules           []*Rule          `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+	// We alert when every sample in the window is above the threshold
+	ConcurrentBuildsThreshold int64 `protobuf:"varint,5,opt,name=concurrent_builds_threshold,json=concurrentBuildsThreshold,proto3" json:"concurrent_builds_threshold,omitempty"`
+	ConcurrentBuildsWindow    int32 `protobuf:"varint,6,opt,name=concurrent_builds_window,json=concurrentBuildsWindow,proto3" json:"concurrent_builds_window,omitempty"`
+	// The discovery servers we find friends through, tried in order
+	DiscoverySeeds []string `protobuf:"bytes,7,rep,name=discovery_seeds,json=discoverySeeds,proto3" json:"discovery_seeds,omitempty"`
+	// How long, in seconds, before we repeat a notification for an unchanged alert
+	RenotifyInterval int64 `protobuf:"varint,8,opt,name=renotify_interval,json=renotifyInterval,proto3" json:"renotify_interval,omitempty"`
+	// Routes are tried in order, the first matching route decides what happens to an alert
+	Routes []*Route `protobuf:"bytes,9,rep,name=routes,proto3" json:"routes,omitempty"`
+	// How often, in seconds, we send out the digest
+	DigestInterval int64       `protobuf:"varint,10,opt,name=digest_interval,json=digestInterval,proto3" json:"digest_interval,omitempty"`
+	Notifiers      []*Notifier `protobuf:"bytes,11,rep,name=notifiers,proto3" json:"notifiers,omitempty"`
+	// Open alerts not raised within this many seconds, or the health multiple of the
+	// interval of the task that raised them if that is longer, are resolved when we
+	// become master
+	StaleAlertAge int64 `protobuf:"varint,12,opt,name=stale_alert_age,json=staleAlertAge,proto3" json:"stale_alert_age,omitempty"`
+	// We are unhealthy once a task has gone this many of its intervals without succeeding
+	HealthMultiple int32 `protobuf:"varint,13,opt,name=health_multiple,json=healthMultiple,proto3" json:"health_multiple,omitempty"`
+	// An external monitor we call every minute to show we are still alive
+	HeartbeatUrl string `protobuf:"bytes,14,opt,name=heartbeat_url,json=heartbeatUrl,proto3" json:"heartbeat_url,omitempty"`
+	// The number of replicas we expect of each service. A service expected to have none
+	// is allowed to leave the registry.
+	ExpectedReplicas map[string]int32 `protobuf:"bytes,15,rep,name=expected_replicas,json=expectedReplicas,proto3" json:"expected_replicas,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// We alert when a job restarts this many times within the window, in seconds
+	CrashLoopRestarts int32 `protobuf:"varint,16,opt,name=crash_loop_restarts,json=crashLoopRestarts,proto3" json:"crash_loop_restarts,omitempty"`
+	CrashLoopWindow   int64 `protobuf:"varint,17,opt,name=crash_loop_window,json=crashLoopWindow,proto3" json:"crash_loop_window,omitempty"`
+	// How long, in seconds, a job can stay out of the running state before we alert
+	StuckJobGracePeriod int64 `protobuf:"varint,18,opt,name=stuck_job_grace_period,json=stuckJobGracePeriod,proto3" json:"stuck_job_grace_period,omitempty"`
+	// How long, in seconds, a service can go without exactly one master before we alert
+	MasterGracePeriod int64 `protobuf:"varint,19,opt,name=master_grace_period,json=masterGracePeriod,proto3" json:"master_grace_period,omitempty"`
+	// Services that do not elect a master
+	MasterExempt []string `protobuf:"bytes,20,rep,name=master_exempt,json=masterExempt,proto3" json:"master_exempt,omitempty"`
+	// The state key a server reports its start time, in seconds, under
+	StartTimeKey string `protobuf:"bytes,21,opt,name=start_time_key,json=startTimeKey,proto3" json:"start_time_key,omitempty"`
+	// How long, in seconds, a new registration has to start serving before we check it
+	ZombieGracePeriod int64 `protobuf:"varint,22,opt,name=zombie_grace_period,json=zombieGracePeriod,proto3" json:"zombie_grace_period,omitempty"`
+	// How far, in seconds, a server's start time can run ahead of its registration time
+	// before we treat it as a different process, allowing for the two clocks disagreeing
+	ZombieClockSkew      int64    `protobuf:"varint,23,opt,name=zombie_clock_skew,json=zombieClockSkew,proto3" json:"zombie_clock_skew,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Config) Reset()         { *m = Config{} }
+func (m *Config) String() string { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()    {}
+func (*Config) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{4}
+}
+
+func (m *Config) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Config.Unmarshal(m, b)
+}
+func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+}
+func (m *Config) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Config.Merge(m, src)
+}
+func (m *Config) XXX_Size() int {
+	return xxx_messageInfo_Config.Size(m)
+}
+func (m *Config) XXX_DiscardUnknown() {
+	xxx_messageInfo_Config.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Config proto.InternalMessageInfo
+
+func (m *Config) GetVersionGracePeriod() int64 {
+	if m != nil {
+		return m.VersionGracePeriod
+	}
+	return 0
+}
+
+func (m *Config) GetJobGracePeriods() map[string]int64 {
+	if m != nil {
+		return m.JobGracePeriods
+	}
+	return nil
+}
+
+func (m *Config) GetGoPolicy() *GoPolicy {
+	if m != nil {
+		return m.GoPolicy
+	}
+	return nil
+}
+
+func (m *Config) GetRules() []*Rule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *Config) GetConcurrentBuildsThreshold() int64 {
+	if m != nil {
+		return m.ConcurrentBuildsThreshold
+	}
+	return 0
+}
+
+func (m *Config) GetConcurrentBuildsWindow() int32 {
+	if m != nil {
+		return m.ConcurrentBuildsWindow
+	}
+	return 0
+}
+
+func (m *Config) GetDiscoverySeeds() []string {
+	if m != nil {
+		return m.DiscoverySeeds
+	}
+	return nil
+}
+
+func (m *Config) GetRenotifyInterval() int64 {
+	if m != nil {
+		return m.RenotifyInterval
+	}
+	return 0
+}
+
+func (m *Config) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+func (m *Config) GetDigestInterval() int64 {
+	if m != nil {
+		return m.DigestInterval
+	}
+	return 0
+}
+
+func (m *Config) GetNotifiers() []*Notifier {
+	if m != nil {
+		return m.Notifiers
+	}
+	return nil
+}
+
+func (m *Config) GetStaleAlertAge() int64 {
+	if m != nil {
+		return m.StaleAlertAge
+	}
+	return 0
+}
+
+func (m *Config) GetHealthMultiple() int32 {
+	if m != nil {
+		return m.HealthMultiple
+	}
+	return 0
+}
+
+func (m *Config) GetHeartbeatUrl() string {
+	if m != nil {
+		return m.HeartbeatUrl
+	}
+	return ""
+}
+
+func (m *Config) GetExpectedReplicas() map[string]int32 {
+	if m != nil {
+		return m.ExpectedReplicas
+	}
+	return nil
+}
+
+func (m *Config) GetCrashLoopRestarts() int32 {
+	if m != nil {
+		return m.CrashLoopRestarts
+	}
+	return 0
+}
+
+func (m *Config) GetCrashLoopWindow() int64 {
+	if m != nil {
+		return m.CrashLoopWindow
+	}
+	return 0
+}
+
+func (m *Config) GetStuckJobGracePeriod() int64 {
+	if m != nil {
+		return m.StuckJobGracePeriod
+	}
+	return 0
+}
+
+func (m *Config) GetMasterGracePeriod() int64 {
+	if m != nil {
+		return m.MasterGracePeriod
+	}
+	return 0
+}
+
+func (m *Config) GetMasterExempt() []string {
+	if m != nil {
+		return m.MasterExempt
+	}
+	return nil
+}
+
+func (m *Config) GetStartTimeKey() string {
+	if m != nil {
+		return m.StartTimeKey
+	}
+	return ""
+}
+
+func (m *Config) GetZombieGracePeriod() int64 {
+	if m != nil {
+		return m.ZombieGracePeriod
+	}
+	return 0
+}
+
+func (m *Config) GetZombieClockSkew() int64 {
+	if m != nil {
+		return m.ZombieClockSkew
+	}
+	return 0
+}
+
+type Silence struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Every matcher that is set must match for the silence to apply
+	Check      string `protobuf:"bytes,2,opt,name=check,proto3" json:"check,omitempty"`
+	Service    string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	Identifier string `protobuf:"bytes,4,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// Matched against each label of the alert, written as key=value
+	LabelRegex           string   `protobuf:"bytes,5,opt,name=label_regex,json=labelRegex,proto3" json:"label_regex,omitempty"`
+	Start                int64    `protobuf:"varint,6,opt,name=start,proto3" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,7,opt,name=end,proto3" json:"end,omitempty"`
+	Author               string   `protobuf:"bytes,8,opt,name=author,proto3" json:"author,omitempty"`
+	Comment              string   `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Silence) Reset()         { *m = Silence{} }
+func (m *Silence) String() string { return proto.CompactTextString(m) }
+func (*Silence) ProtoMessage()    {}
+func (*Silence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{5}
+}
+
+func (m *Silence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Silence.Unmarshal(m, b)
+}
+func (m *Silence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Silence.Marshal(b, m, deterministic)
+}
+func (m *Silence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Silence.Merge(m, src)
+}
+func (m *Silence) XXX_Size() int {
+	return xxx_messageInfo_Silence.Size(m)
+}
+func (m *Silence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Silence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Silence proto.InternalMessageInfo
+
+func (m *Silence) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Silence) GetCheck() string {
+	if m != nil {
+		return m.Check
+	}
+	return ""
+}
+
+func (m *Silence) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Silence) GetIdentifier() string {
+	if m != nil {
+		return m.Identifier
+	}
+	return ""
+}
+
+func (m *Silence) GetLabelRegex() string {
+	if m != nil {
+		return m.LabelRegex
+	}
+	return ""
+}
+
+func (m *Silence) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *Silence) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *Silence) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Silence) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type Silences struct {
+	Silences             []*Silence `protobuf:"bytes,1,rep,name=silences,proto3" json:"silences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Silences) Reset()         { *m = Silences{} }
+func (m *Silences) String() string { return proto.CompactTextString(m) }
+func (*Silences) ProtoMessage()    {}
+func (*Silences) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{6}
+}
+
+func (m *Silences) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Silences.Unmarshal(m, b)
+}
+func (m *Silences) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Silences.Marshal(b, m, deterministic)
+}
+func (m *Silences) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Silences.Merge(m, src)
+}
+func (m *Silences) XXX_Size() int {
+	return xxx_messageInfo_Silences.Size(m)
+}
+func (m *Silences) XXX_DiscardUnknown() {
+	xxx_messageInfo_Silences.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Silences proto.InternalMessageInfo
+
+func (m *Silences) GetSilences() []*Silence {
+	if m != nil {
+		return m.Silences
+	}
+	return nil
+}
+
+type AlertEvent struct {
+	Timestamp            int64       `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Body                 string      `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	State                Alert_State `protobuf:"varint,3,opt,name=state,proto3,enum=alerter.Alert_State" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *AlertEvent) Reset()         { *m = AlertEvent{} }
+func (m *AlertEvent) String() string { return proto.CompactTextString(m) }
+func (*AlertEvent) ProtoMessage()    {}
+func (*AlertEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{7}
+}
+
+func (m *AlertEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertEvent.Unmarshal(m, b)
+}
+func (m *AlertEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertEvent.Marshal(b, m, deterministic)
+}
+func (m *AlertEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertEvent.Merge(m, src)
+}
+func (m *AlertEvent) XXX_Size() int {
+	return xxx_messageInfo_AlertEvent.Size(m)
+}
+func (m *AlertEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertEvent proto.InternalMessageInfo
+
+func (m *AlertEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *AlertEvent) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetState() Alert_State {
+	if m != nil {
+		return m.State
+	}
+	return Alert_UNKNOWN
+}
+
+type Alert struct {
+	// The fingerprint of the alert, built from the check and the subject
+	Key              string        `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Title            string        `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Body             string        `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	FirstRaised      int64         `protobuf:"varint,4,opt,name=first_raised,json=firstRaised,proto3" json:"first_raised,omitempty"`
+	LastRaised       int64         `protobuf:"varint,5,opt,name=last_raised,json=lastRaised,proto3" json:"last_raised,omitempty"`
+	Count            int32         `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	History          []*AlertEvent `protobuf:"bytes,7,rep,name=history,proto3" json:"history,omitempty"`
+	Check            string        `protobuf:"bytes,8,opt,name=check,proto3" json:"check,omitempty"`
+	Subject          string        `protobuf:"bytes,9,opt,name=subject,proto3" json:"subject,omitempty"`
+	State            Alert_State   `protobuf:"varint,10,opt,name=state,proto3,enum=alerter.Alert_State" json:"state,omitempty"`
+	AcknowledgedTime int64         `protobuf:"varint,11,opt,name=acknowledged_time,json=acknowledgedTime,proto3" json:"acknowledged_time,omitempty"`
+	ResolvedTime     int64         `protobuf:"varint,12,opt,name=resolved_time,json=resolvedTime,proto3" json:"resolved_time,omitempty"`
+	LastNotified     int64         `protobuf:"varint,13,opt,name=last_notified,json=lastNotified,proto3" json:"last_notified,omitempty"`
+	// The number of repeats we did not pass on
+	Suppressed int32    `protobuf:"varint,14,opt,name=suppressed,proto3" json:"suppressed,omitempty"`
+	Severity   Severity `protobuf:"varint,15,opt,name=severity,proto3,enum=alerter.Severity" json:"severity,omitempty"`
+	// Labels describing what the alert is about, e.g. service and identifier
+	Labels map[string]string `protobuf:"bytes,16,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Set when the alert was last held back by a silence
+	Silenced bool `protobuf:"varint,17,opt,name=silenced,proto3" json:"silenced,omitempty"`
+	// The issue we opened for this alert, closed once the alert resolves
+	IssueNumber int32 `protobuf:"varint,18,opt,name=issue_number,json=issueNumber,proto3" json:"issue_number,omitempty"`
+	// The task whose check last raised the alert
+	Task                 string   `protobuf:"bytes,19,opt,name=task,proto3" json:"task,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Alert) Reset()         { *m = Alert{} }
+func (m *Alert) String() string { return proto.CompactTextString(m) }
+func (*Alert) ProtoMessage()    {}
+func (*Alert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{8}
+}
+
+func (m *Alert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Alert.Unmarshal(m, b)
+}
+func (m *Alert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Alert.Marshal(b, m, deterministic)
+}
+func (m *Alert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alert.Merge(m, src)
+}
+func (m *Alert) XXX_Size() int {
+	return xxx_messageInfo_Alert.Size(m)
+}
+func (m *Alert) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Alert proto.InternalMessageInfo
+
+func (m *Alert) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Alert) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Alert) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Alert) GetFirstRaised() int64 {
+	if m != nil {
+		return m.FirstRaised
+	}
+	return 0
+}
+
+func (m *Alert) GetLastRaised() int64 {
+	if m != nil {
+		return m.LastRaised
+	}
+	return 0
+}
+
+func (m *Alert) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *Alert) GetHistory() []*AlertEvent {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+func (m *Alert) GetCheck() string {
+	if m != nil {
+		return m.Check
+	}
+	return ""
+}
+
+func (m *Alert) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *Alert) GetState() Alert_State {
+	if m != nil {
+		return m.State
+	}
+	return Alert_UNKNOWN
+}
+
+func (m *Alert) GetAcknowledgedTime() int64 {
+	if m != nil {
+		return m.AcknowledgedTime
+	}
+	return 0
+}
+
+func (m *Alert) GetResolvedTime() int64 {
+	if m != nil {
+		return m.ResolvedTime
+	}
+	return 0
+}
+
+func (m *Alert) GetLastNotified() int64 {
+	if m != nil {
+		return m.LastNotified
+	}
+	return 0
+}
+
+func (m *Alert) GetSuppressed() int32 {
+	if m != nil {
+		return m.Suppressed
+	}
+	return 0
+}
+
+func (m *Alert) GetSeverity() Severity {
+	if m != nil {
+		return m.Severity
+	}
+	return Severity_UNSET
+}
+
+func (m *Alert) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Alert) GetSilenced() bool {
+	if m != nil {
+		return m.Silenced
+	}
+	return false
+}
+
+func (m *Alert) GetIssueNumber() int32 {
+	if m != nil {
+		return m.IssueNumber
+	}
+	return 0
+}
+
+func (m *Alert) GetTask() string {
+	if m != nil {
+		return m.Task
+	}
+	return ""
+}
+
+// AlerterState is everything we need to carry over to the next master
+type AlerterState struct {
+	Alerts           []*Alert         `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	LastMismatchTime map[string]int64 `protobuf:"bytes,2,rep,name=last_mismatch_time,json=lastMismatchTime,proto3" json:"last_mismatch_time,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	HighCpu          map[string]int64 `protobuf:"bytes,3,rep,name=high_cpu,json=highCpu,proto3" json:"high_cpu,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	RuleBreaches     map[string]int64 `protobuf:"bytes,4,rep,name=rule_breaches,json=ruleBreaches,proto3" json:"rule_breaches,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	BuildSamples     []int64          `protobuf:"varint,5,rep,packed,name=build_samples,json=buildSamples,proto3" json:"build_samples,omitempty"`
+	// The number of registry entries for each service from the last snapshot
+	ServiceCounts map[string]int32 `protobuf:"bytes,6,rep,name=service_counts,json=serviceCounts,proto3" json:"service_counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The last start time reported for each job, and the restarts seen within the window
+	JobStarts   map[string]int64     `protobuf:"bytes,7,rep,name=job_starts,json=jobStarts,proto3" json:"job_starts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	JobRestarts map[string]*Restarts `protobuf:"bytes,8,rep,name=job_restarts,json=jobRestarts,proto3" json:"job_restarts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// When each job was first seen out of the running state
+	JobStuck map[string]int64 `protobuf:"bytes,9,rep,name=job_stuck,json=jobStuck,proto3" json:"job_stuck,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// When each service was first seen without exactly one master
+	MasterProblems map[string]int64 `protobuf:"bytes,10,rep,name=master_problems,json=masterProblems,proto3" json:"master_problems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The services that have had a master at some point
+	Mastered             []string `protobuf:"bytes,11,rep,name=mastered,proto3" json:"mastered,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlerterState) Reset()         { *m = AlerterState{} }
+func (m *AlerterState) String() string { return proto.CompactTextString(m) }
+func (*AlerterState) ProtoMessage()    {}
+func (*AlerterState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{9}
+}
+
+func (m *AlerterState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlerterState.Unmarshal(m, b)
+}
+func (m *AlerterState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlerterState.Marshal(b, m, deterministic)
+}
+func (m *AlerterState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlerterState.Merge(m, src)
+}
+func (m *AlerterState) XXX_Size() int {
+	return xxx_messageInfo_AlerterState.Size(m)
+}
+func (m *AlerterState) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlerterState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlerterState proto.InternalMessageInfo
+
+func (m *AlerterState) GetAlerts() []*Alert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+func (m *AlerterState) GetLastMismatchTime() map[string]int64 {
+	if m != nil {
+		return m.LastMismatchTime
+	}
+	return nil
+}
+
+func (m *AlerterState) GetHighCpu() map[string]int64 {
+	if m != nil {
+		return m.HighCpu
+	}
+	return nil
+}
+
+func (m *AlerterState) GetRuleBreaches() map[string]int64 {
+	if m != nil {
+		return m.RuleBreaches
+	}
+	return nil
+}
+
+func (m *AlerterState) GetBuildSamples() []int64 {
+	if m != nil {
+		return m.BuildSamples
+	}
+	return nil
+}
+
+func (m *AlerterState) GetServiceCounts() map[string]int32 {
+	if m != nil {
+		return m.ServiceCounts
+	}
+	return nil
+}
+
+func (m *AlerterState) GetJobStarts() map[string]int64 {
+	if m != nil {
+		return m.JobStarts
+	}
+	return nil
+}
+
+func (m *AlerterState) GetJobRestarts() map[string]*Restarts {
+	if m != nil {
+		return m.JobRestarts
+	}
+	return nil
+}
+
+func (m *AlerterState) GetJobStuck() map[string]int64 {
+	if m != nil {
+		return m.JobStuck
+	}
+	return nil
+}
+
+func (m *AlerterState) GetMasterProblems() map[string]int64 {
+	if m != nil {
+		return m.MasterProblems
+	}
+	return nil
+}
+
+func (m *AlerterState) GetMastered() []string {
+	if m != nil {
+		return m.Mastered
+	}
+	return nil
+}
+
+// Heartbeat records the latest run of a task, whichever alerter ran it
+type Heartbeat struct {
+	Task string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// The alerter that ran the task
+	Instance    string `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
+	FirstRun    int64  `protobuf:"varint,3,opt,name=first_run,json=firstRun,proto3" json:"first_run,omitempty"`
+	LastRun     int64  `protobuf:"varint,4,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	LastSuccess int64  `protobuf:"varint,5,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	// How long, in seconds, the task asked to wait before its next run
+	Interval             int64    `protobuf:"varint,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Heartbeat) Reset()         { *m = Heartbeat{} }
+func (m *Heartbeat) String() string { return proto.CompactTextString(m) }
+func (*Heartbeat) ProtoMessage()    {}
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{10}
+}
+
+func (m *Heartbeat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Heartbeat.Unmarshal(m, b)
+}
+func (m *Heartbeat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Heartbeat.Marshal(b, m, deterministic)
+}
+func (m *Heartbeat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Heartbeat.Merge(m, src)
+}
+func (m *Heartbeat) XXX_Size() int {
+	return xxx_messageInfo_Heartbeat.Size(m)
+}
+func (m *Heartbeat) XXX_DiscardUnknown() {
+	xxx_messageInfo_Heartbeat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Heartbeat proto.InternalMessageInfo
+
+func (m *Heartbeat) GetTask() string {
+	if m != nil {
+		return m.Task
+	}
+	return ""
+}
+
+func (m *Heartbeat) GetInstance() string {
+	if m != nil {
+		return m.Instance
+	}
+	return ""
+}
+
+func (m *Heartbeat) GetFirstRun() int64 {
+	if m != nil {
+		return m.FirstRun
+	}
+	return 0
+}
+
+func (m *Heartbeat) GetLastRun() int64 {
+	if m != nil {
+		return m.LastRun
+	}
+	return 0
+}
+
+func (m *Heartbeat) GetLastSuccess() int64 {
+	if m != nil {
+		return m.LastSuccess
+	}
+	return 0
+}
+
+func (m *Heartbeat) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+type Restarts struct {
+	Times                []int64  `protobuf:"varint,1,rep,packed,name=times,proto3" json:"times,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Restarts) Reset()         { *m = Restarts{} }
+func (m *Restarts) String() string { return proto.CompactTextString(m) }
+func (*Restarts) ProtoMessage()    {}
+func (*Restarts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{11}
+}
+
+func (m *Restarts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Restarts.Unmarshal(m, b)
+}
+func (m *Restarts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Restarts.Marshal(b, m, deterministic)
+}
+func (m *Restarts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Restarts.Merge(m, src)
+}
+func (m *Restarts) XXX_Size() int {
+	return xxx_messageInfo_Restarts.Size(m)
+}
+func (m *Restarts) XXX_DiscardUnknown() {
+	xxx_messageInfo_Restarts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Restarts proto.InternalMessageInfo
+
+func (m *Restarts) GetTimes() []int64 {
+	if m != nil {
+		return m.Times
+	}
+	return nil
+}
+
+type FriendNode struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The friends this node reports, empty if we could not reach it
+	Friends     []string `protobuf:"bytes,2,rep,name=friends,proto3" json:"friends,omitempty"`
+	Unreachable bool     `protobuf:"varint,3,opt,name=unreachable,proto3" json:"unreachable,omitempty"`
+	// The connected component the node belongs to, numbered from zero
+	Component            int32    `protobuf:"varint,4,opt,name=component,proto3" json:"component,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FriendNode) Reset()         { *m = FriendNode{} }
+func (m *FriendNode) String() string { return proto.CompactTextString(m) }
+func (*FriendNode) ProtoMessage()    {}
+func (*FriendNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{12}
+}
+
+func (m *FriendNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FriendNode.Unmarshal(m, b)
+}
+func (m *FriendNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FriendNode.Marshal(b, m, deterministic)
+}
+func (m *FriendNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FriendNode.Merge(m, src)
+}
+func (m *FriendNode) XXX_Size() int {
+	return xxx_messageInfo_FriendNode.Size(m)
+}
+func (m *FriendNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_FriendNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FriendNode proto.InternalMessageInfo
+
+func (m *FriendNode) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *FriendNode) GetFriends() []string {
+	if m != nil {
+		return m.Friends
+	}
+	return nil
+}
+
+func (m *FriendNode) GetUnreachable() bool {
+	if m != nil {
+		return m.Unreachable
+	}
+	return false
+}
+
+func (m *FriendNode) GetComponent() int32 {
+	if m != nil {
+		return m.Component
+	}
+	return 0
+}
+
+type FriendGraph struct {
+	Nodes []*FriendNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// The seeds we started walking the graph from
+	Seeds                []string `protobuf:"bytes,2,rep,name=seeds,proto3" json:"seeds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FriendGraph) Reset()         { *m = FriendGraph{} }
+func (m *FriendGraph) String() string { return proto.CompactTextString(m) }
+func (*FriendGraph) ProtoMessage()    {}
+func (*FriendGraph) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{13}
+}
+
+func (m *FriendGraph) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FriendGraph.Unmarshal(m, b)
+}
+func (m *FriendGraph) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FriendGraph.Marshal(b, m, deterministic)
+}
+func (m *FriendGraph) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FriendGraph.Merge(m, src)
+}
+func (m *FriendGraph) XXX_Size() int {
+	return xxx_messageInfo_FriendGraph.Size(m)
+}
+func (m *FriendGraph) XXX_DiscardUnknown() {
+	xxx_messageInfo_FriendGraph.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FriendGraph proto.InternalMessageInfo
+
+func (m *FriendGraph) GetNodes() []*FriendNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *FriendGraph) GetSeeds() []string {
+	if m != nil {
+		return m.Seeds
+	}
+	return nil
+}
+
+type ListAlertsRequest struct {
+	IncludeResolved      bool     `protobuf:"varint,1,opt,name=include_resolved,json=includeResolved,proto3" json:"include_resolved,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAlertsRequest) Reset()         { *m = ListAlertsRequest{} }
+func (m *ListAlertsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAlertsRequest) ProtoMessage()    {}
+func (*ListAlertsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{14}
+}
+
+func (m *ListAlertsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAlertsRequest.Unmarshal(m, b)
+}
+func (m *ListAlertsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAlertsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAlertsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAlertsRequest.Merge(m, src)
+}
+func (m *ListAlertsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAlertsRequest.Size(m)
+}
+func (m *ListAlertsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAlertsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAlertsRequest proto.InternalMessageInfo
+
+func (m *ListAlertsRequest) GetIncludeResolved() bool {
+	if m != nil {
+		return m.IncludeResolved
+	}
+	return false
+}
+
+type ListAlertsResponse struct {
+	Alerts               []*Alert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAlertsResponse) Reset()         { *m = ListAlertsResponse{} }
+func (m *ListAlertsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAlertsResponse) ProtoMessage()    {}
+func (*ListAlertsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{15}
+}
+
+func (m *ListAlertsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAlertsResponse.Unmarshal(m, b)
+}
+func (m *ListAlertsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAlertsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAlertsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAlertsResponse.Merge(m, src)
+}
+func (m *ListAlertsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAlertsResponse.Size(m)
+}
+func (m *ListAlertsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAlertsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAlertsResponse proto.InternalMessageInfo
+
+func (m *ListAlertsResponse) GetAlerts() []*Alert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+type GetAlertRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAlertRequest) Reset()         { *m = GetAlertRequest{} }
+func (m *GetAlertRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAlertRequest) ProtoMessage()    {}
+func (*GetAlertRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{16}
+}
+
+func (m *GetAlertRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAlertRequest.Unmarshal(m, b)
+}
+func (m *GetAlertRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAlertRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAlertRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAlertRequest.Merge(m, src)
+}
+func (m *GetAlertRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAlertRequest.Size(m)
+}
+func (m *GetAlertRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAlertRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAlertRequest proto.InternalMessageInfo
+
+func (m *GetAlertRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type GetAlertResponse struct {
+	Alert                *Alert   `protobuf:"bytes,1,opt,name=alert,proto3" json:"alert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAlertResponse) Reset()         { *m = GetAlertResponse{} }
+func (m *GetAlertResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAlertResponse) ProtoMessage()    {}
+func (*GetAlertResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{17}
+}
+
+func (m *GetAlertResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAlertResponse.Unmarshal(m, b)
+}
+func (m *GetAlertResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAlertResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAlertResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAlertResponse.Merge(m, src)
+}
+func (m *GetAlertResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAlertResponse.Size(m)
+}
+func (m *GetAlertResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAlertResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAlertResponse proto.InternalMessageInfo
+
+func (m *GetAlertResponse) GetAlert() *Alert {
+	if m != nil {
+		return m.Alert
+	}
+	return nil
+}
+
+type AcknowledgeAlertRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcknowledgeAlertRequest) Reset()         { *m = AcknowledgeAlertRequest{} }
+func (m *AcknowledgeAlertRequest) String() string { return proto.CompactTextString(m) }
+func (*AcknowledgeAlertRequest) ProtoMessage()    {}
+func (*AcknowledgeAlertRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{18}
+}
+
+func (m *AcknowledgeAlertRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcknowledgeAlertRequest.Unmarshal(m, b)
+}
+func (m *AcknowledgeAlertRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcknowledgeAlertRequest.Marshal(b, m, deterministic)
+}
+func (m *AcknowledgeAlertRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcknowledgeAlertRequest.Merge(m, src)
+}
+func (m *AcknowledgeAlertRequest) XXX_Size() int {
+	return xxx_messageInfo_AcknowledgeAlertRequest.Size(m)
+}
+func (m *AcknowledgeAlertRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcknowledgeAlertRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcknowledgeAlertRequest proto.InternalMessageInfo
+
+func (m *AcknowledgeAlertRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type AcknowledgeAlertResponse struct {
+	Alert                *Alert   `protobuf:"bytes,1,opt,name=alert,proto3" json:"alert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcknowledgeAlertResponse) Reset()         { *m = AcknowledgeAlertResponse{} }
+func (m *AcknowledgeAlertResponse) String() string { return proto.CompactTextString(m) }
+func (*AcknowledgeAlertResponse) ProtoMessage()    {}
+func (*AcknowledgeAlertResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{19}
+}
+
+func (m *AcknowledgeAlertResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcknowledgeAlertResponse.Unmarshal(m, b)
+}
+func (m *AcknowledgeAlertResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcknowledgeAlertResponse.Marshal(b, m, deterministic)
+}
+func (m *AcknowledgeAlertResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcknowledgeAlertResponse.Merge(m, src)
+}
+func (m *AcknowledgeAlertResponse) XXX_Size() int {
+	return xxx_messageInfo_AcknowledgeAlertResponse.Size(m)
+}
+func (m *AcknowledgeAlertResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcknowledgeAlertResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcknowledgeAlertResponse proto.InternalMessageInfo
+
+func (m *AcknowledgeAlertResponse) GetAlert() *Alert {
+	if m != nil {
+		return m.Alert
+	}
+	return nil
+}
+
+type AddSilenceRequest struct {
+	Silence              *Silence `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddSilenceRequest) Reset()         { *m = AddSilenceRequest{} }
+func (m *AddSilenceRequest) String() string { return proto.CompactTextString(m) }
+func (*AddSilenceRequest) ProtoMessage()    {}
+func (*AddSilenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{20}
+}
+
+func (m *AddSilenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddSilenceRequest.Unmarshal(m, b)
+}
+func (m *AddSilenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddSilenceRequest.Marshal(b, m, deterministic)
+}
+func (m *AddSilenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddSilenceRequest.Merge(m, src)
+}
+func (m *AddSilenceRequest) XXX_Size() int {
+	return xxx_messageInfo_AddSilenceRequest.Size(m)
+}
+func (m *AddSilenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddSilenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddSilenceRequest proto.InternalMessageInfo
+
+func (m *AddSilenceRequest) GetSilence() *Silence {
+	if m != nil {
+		return m.Silence
+	}
+	return nil
+}
+
+type AddSilenceResponse struct {
+	Silence              *Silence `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddSilenceResponse) Reset()         { *m = AddSilenceResponse{} }
+func (m *AddSilenceResponse) String() string { return proto.CompactTextString(m) }
+func (*AddSilenceResponse) ProtoMessage()    {}
+func (*AddSilenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{21}
+}
+
+func (m *AddSilenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddSilenceResponse.Unmarshal(m, b)
+}
+func (m *AddSilenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddSilenceResponse.Marshal(b, m, deterministic)
+}
+func (m *AddSilenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddSilenceResponse.Merge(m, src)
+}
+func (m *AddSilenceResponse) XXX_Size() int {
+	return xxx_messageInfo_AddSilenceResponse.Size(m)
+}
+func (m *AddSilenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddSilenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddSilenceResponse proto.InternalMessageInfo
+
+func (m *AddSilenceResponse) GetSilence() *Silence {
+	if m != nil {
+		return m.Silence
+	}
+	return nil
+}
+
+type ListSilencesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSilencesRequest) Reset()         { *m = ListSilencesRequest{} }
+func (m *ListSilencesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSilencesRequest) ProtoMessage()    {}
+func (*ListSilencesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{22}
+}
+
+func (m *ListSilencesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSilencesRequest.Unmarshal(m, b)
+}
+func (m *ListSilencesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSilencesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSilencesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSilencesRequest.Merge(m, src)
+}
+func (m *ListSilencesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSilencesRequest.Size(m)
+}
+func (m *ListSilencesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSilencesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSilencesRequest proto.InternalMessageInfo
+
+type ListSilencesResponse struct {
+	Silences             []*Silence `protobuf:"bytes,1,rep,name=silences,proto3" json:"silences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListSilencesResponse) Reset()         { *m = ListSilencesResponse{} }
+func (m *ListSilencesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSilencesResponse) ProtoMessage()    {}
+func (*ListSilencesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{23}
+}
+
+func (m *ListSilencesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSilencesResponse.Unmarshal(m, b)
+}
+func (m *ListSilencesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSilencesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSilencesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSilencesResponse.Merge(m, src)
+}
+func (m *ListSilencesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSilencesResponse.Size(m)
+}
+func (m *ListSilencesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSilencesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSilencesResponse proto.InternalMessageInfo
+
+func (m *ListSilencesResponse) GetSilences() []*Silence {
+	if m != nil {
+		return m.Silences
+	}
+	return nil
+}
+
+type DeleteSilenceRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSilenceRequest) Reset()         { *m = DeleteSilenceRequest{} }
+func (m *DeleteSilenceRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteSilenceRequest) ProtoMessage()    {}
+func (*DeleteSilenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{24}
+}
+
+func (m *DeleteSilenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSilenceRequest.Unmarshal(m, b)
+}
+func (m *DeleteSilenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSilenceRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteSilenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSilenceRequest.Merge(m, src)
+}
+func (m *DeleteSilenceRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteSilenceRequest.Size(m)
+}
+func (m *DeleteSilenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSilenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSilenceRequest proto.InternalMessageInfo
+
+func (m *DeleteSilenceRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteSilenceResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSilenceResponse) Reset()         { *m = DeleteSilenceResponse{} }
+func (m *DeleteSilenceResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteSilenceResponse) ProtoMessage()    {}
+func (*DeleteSilenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{25}
+}
+
+func (m *DeleteSilenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSilenceResponse.Unmarshal(m, b)
+}
+func (m *DeleteSilenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSilenceResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteSilenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSilenceResponse.Merge(m, src)
+}
+func (m *DeleteSilenceResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteSilenceResponse.Size(m)
+}
+func (m *DeleteSilenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSilenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSilenceResponse proto.InternalMessageInfo
+
+type RunTaskRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunTaskRequest) Reset()         { *m = RunTaskRequest{} }
+func (m *RunTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RunTaskRequest) ProtoMessage()    {}
+func (*RunTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{26}
+}
+
+func (m *RunTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunTaskRequest.Unmarshal(m, b)
+}
+func (m *RunTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RunTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunTaskRequest.Merge(m, src)
+}
+func (m *RunTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RunTaskRequest.Size(m)
+}
+func (m *RunTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunTaskRequest proto.InternalMessageInfo
+
+func (m *RunTaskRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RunTaskResponse struct {
+	NextRunTime          int64    `protobuf:"varint,1,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunTaskResponse) Reset()         { *m = RunTaskResponse{} }
+func (m *RunTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RunTaskResponse) ProtoMessage()    {}
+func (*RunTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{27}
+}
+
+func (m *RunTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunTaskResponse.Unmarshal(m, b)
+}
+func (m *RunTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RunTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunTaskResponse.Merge(m, src)
+}
+func (m *RunTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RunTaskResponse.Size(m)
+}
+func (m *RunTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunTaskResponse proto.InternalMessageInfo
+
+func (m *RunTaskResponse) GetNextRunTime() int64 {
+	if m != nil {
+		return m.NextRunTime
+	}
+	return 0
+}
+
+type GetFriendGraphRequest struct {
+	Format               GetFriendGraphRequest_Format `protobuf:"varint,1,opt,name=format,proto3,enum=alerter.GetFriendGraphRequest_Format" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *GetFriendGraphRequest) Reset()         { *m = GetFriendGraphRequest{} }
+func (m *GetFriendGraphRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFriendGraphRequest) ProtoMessage()    {}
+func (*GetFriendGraphRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{28}
+}
+
+func (m *GetFriendGraphRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFriendGraphRequest.Unmarshal(m, b)
+}
+func (m *GetFriendGraphRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFriendGraphRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFriendGraphRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFriendGraphRequest.Merge(m, src)
+}
+func (m *GetFriendGraphRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFriendGraphRequest.Size(m)
+}
+func (m *GetFriendGraphRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFriendGraphRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFriendGraphRequest proto.InternalMessageInfo
+
+func (m *GetFriendGraphRequest) GetFormat() GetFriendGraphRequest_Format {
+	if m != nil {
+		return m.Format
+	}
+	return GetFriendGraphRequest_JSON
+}
+
+type GetFriendGraphResponse struct {
+	Graph *FriendGraph `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	// The graph rendered in the requested format
+	Rendered             string   `protobuf:"bytes,2,opt,name=rendered,proto3" json:"rendered,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFriendGraphResponse) Reset()         { *m = GetFriendGraphResponse{} }
+func (m *GetFriendGraphResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFriendGraphResponse) ProtoMessage()    {}
+func (*GetFriendGraphResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3d85249a90ba383, []int{29}
+}
+
+func (m *GetFriendGraphResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFriendGraphResponse.Unmarshal(m, b)
+}
+func (m *GetFriendGraphResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFriendGraphResponse.Marshal(b, m, deterministic)
+}
+func (m *GetFriendGraphResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFriendGraphResponse.Merge(m, src)
+}
+func (m *GetFriendGraphResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFriendGraphResponse.Size(m)
+}
+func (m *GetFriendGraphResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFriendGraphResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFriendGraphResponse proto.InternalMessageInfo
+
+func (m *GetFriendGraphResponse) GetGraph() *FriendGraph {
+	if m != nil {
+		return m.Graph
+	}
+	return nil
+}
+
+func (m *GetFriendGraphResponse) GetRendered() string {
+	if m != nil {
+		return m.Rendered
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("alerter.Severity", Severity_name, Severity_value)
+	proto.RegisterEnum("alerter.Notifier_Type", Notifier_Type_name, Notifier_Type_value)
+	proto.RegisterEnum("alerter.Route_Action", Route_Action_name, Route_Action_value)
+	proto.RegisterEnum("alerter.Rule_Field", Rule_Field_name, Rule_Field_value)
+	proto.RegisterEnum("alerter.Rule_Comparator", Rule_Comparator_name, Rule_Comparator_value)
+	proto.RegisterEnum("alerter.Alert_State", Alert_State_name, Alert_State_value)
+	proto.RegisterEnum("alerter.GetFriendGraphRequest_Format", GetFriendGraphRequest_Format_name, GetFriendGraphRequest_Format_value)
+	proto.RegisterType((*Notifier)(nil), "alerter.Notifier")
+	proto.RegisterType((*Route)(nil), "alerter.Route")
+	proto.RegisterType((*GoPolicy)(nil), "alerter.GoPolicy")
+	proto.RegisterType((*Rule)(nil), "alerter.Rule")
+	proto.RegisterType((*Config)(nil), "alerter.Config")
+	proto.RegisterMapType((map[string]int32)(nil), "alerter.Config.ExpectedReplicasEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "alerter.Config.JobGracePeriodsEntry")
+	proto.RegisterType((*Silence)(nil), "alerter.Silence")
+	proto.RegisterType((*Silences)(nil), "alerter.Silences")
+	proto.RegisterType((*AlertEvent)(nil), "alerter.AlertEvent")
+	proto.RegisterType((*Alert)(nil), "alerter.Alert")
+	proto.RegisterMapType((map[string]string)(nil), "alerter.Alert.LabelsEntry")
+	proto.RegisterType((*AlerterState)(nil), "alerter.AlerterState")
+	proto.RegisterMapType((map[string]int64)(nil), "alerter.AlerterState.HighCpuEntry")
+	proto.RegisterMapType((map[string]*Restarts)(nil), "alerter.AlerterState.JobRestartsEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "alerter.AlerterState.JobStartsEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "alerter.AlerterState.JobStuckEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "alerter.AlerterState.LastMismatchTimeEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "alerter.AlerterState.MasterProblemsEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "alerter.AlerterState.RuleBreachesEntry")
+	proto.RegisterMapType((map[string]int32)(nil), "alerter.AlerterState.ServiceCountsEntry")
+	proto.RegisterType((*Heartbeat)(nil), "alerter.Heartbeat")
+	proto.RegisterType((*Restarts)(nil), "alerter.Restarts")
+	proto.RegisterType((*FriendNode)(nil), "alerter.FriendNode")
+	proto.RegisterType((*FriendGraph)(nil), "alerter.FriendGraph")
+	proto.RegisterType((*ListAlertsRequest)(nil), "alerter.ListAlertsRequest")
+	proto.RegisterType((*ListAlertsResponse)(nil), "alerter.ListAlertsResponse")
+	proto.RegisterType((*GetAlertRequest)(nil), "alerter.GetAlertRequest")
+	proto.RegisterType((*GetAlertResponse)(nil), "alerter.GetAlertResponse")
+	proto.RegisterType((*AcknowledgeAlertRequest)(nil), "alerter.AcknowledgeAlertRequest")
+	proto.RegisterType((*AcknowledgeAlertResponse)(nil), "alerter.AcknowledgeAlertResponse")
+	proto.RegisterType((*AddSilenceRequest)(nil), "alerter.AddSilenceRequest")
+	proto.RegisterType((*AddSilenceResponse)(nil), "alerter.AddSilenceResponse")
+	proto.RegisterType((*ListSilencesRequest)(nil), "alerter.ListSilencesRequest")
+	proto.RegisterType((*ListSilencesResponse)(nil), "alerter.ListSilencesResponse")
+	proto.RegisterType((*DeleteSilenceRequest)(nil), "alerter.DeleteSilenceRequest")
+	proto.RegisterType((*DeleteSilenceResponse)(nil), "alerter.DeleteSilenceResponse")
+	proto.RegisterType((*RunTaskRequest)(nil), "alerter.RunTaskRequest")
+	proto.RegisterType((*RunTaskResponse)(nil), "alerter.RunTaskResponse")
+	proto.RegisterType((*GetFriendGraphRequest)(nil), "alerter.GetFriendGraphRequest")
+	proto.RegisterType((*GetFriendGraphResponse)(nil), "alerter.GetFriendGraphResponse")
+}
+
+func init() { proto.RegisterFile("alerter.proto", fileDescriptor_c3d85249a90ba383) }
+
+var fileDescriptor_c3d85249a90ba383 = []byte{
+	// 2610 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0x4b, 0x73, 0x1b, 0xc7,
+	0xb5, 0x16, 0x08, 0xe2, 0x75, 0xf0, 0xe0, 0xb0, 0x45, 0x49, 0x63, 0xc8, 0xd7, 0xa6, 0x47, 0xb2,
+	0x1e, 0xf4, 0x35, 0x75, 0x8b, 0xae, 0x5b, 0xa5, 0x38, 0x7e, 0x08, 0x22, 0x41, 0x08, 0x36, 0x04,
+	0xca, 0x0d, 0xc8, 0x8e, 0xb3, 0x99, 0x0c, 0x66, 0x9a, 0xc0, 0x90, 0x83, 0x19, 0xa4, 0x67, 0x86,
+	0x12, 0xb3, 0x4a, 0x55, 0xfe, 0x51, 0x36, 0x59, 0x65, 0x97, 0x9f, 0x92, 0x5d, 0xaa, 0xb2, 0xc8,
+	0x22, 0xeb, 0x54, 0x9f, 0xee, 0x79, 0x00, 0x84, 0xac, 0x70, 0xc5, 0xe9, 0xef, 0x7c, 0x7d, 0x4e,
+	0x3f, 0xce, 0x0b, 0x4d, 0x68, 0x5a, 0x1e, 0xe3, 0x11, 0xe3, 0xfb, 0x0b, 0x1e, 0x44, 0x01, 0xa9,
+	0xa8, 0xa1, 0xf1, 0xd7, 0x02, 0x54, 0x87, 0x41, 0xe4, 0x9e, 0xba, 0x8c, 0x13, 0x02, 0x9b, 0xbe,
+	0x35, 0x67, 0x7a, 0x61, 0xb7, 0xf0, 0xa8, 0x46, 0xf1, 0x9b, 0xec, 0xc1, 0x66, 0x74, 0xb9, 0x60,
+	0xfa, 0xc6, 0x6e, 0xe1, 0x51, 0xeb, 0xe0, 0xf6, 0x7e, 0xa2, 0x27, 0x99, 0xb4, 0x3f, 0xbe, 0x5c,
+	0x30, 0x8a, 0x1c, 0xa2, 0x43, 0xc5, 0x72, 0x1c, 0xce, 0xc2, 0x50, 0x2f, 0xa2, 0x8a, 0x64, 0x28,
+	0x34, 0x9f, 0xf2, 0x60, 0xae, 0x6f, 0x4a, 0xcd, 0xe2, 0x9b, 0xb4, 0x60, 0x23, 0x0a, 0xf4, 0xd2,
+	0x6e, 0xf1, 0x51, 0x8d, 0x6e, 0x44, 0x81, 0xf1, 0x05, 0x6c, 0x0a, 0x5d, 0xa4, 0x06, 0xa5, 0xfe,
+	0x68, 0xf4, 0xba, 0xab, 0xdd, 0x10, 0x9f, 0xdd, 0x97, 0x9d, 0xfe, 0x40, 0x2b, 0x90, 0x3a, 0x54,
+	0x7e, 0xea, 0x3e, 0x7f, 0x71, 0x72, 0xf2, 0xbd, 0xb6, 0x41, 0xaa, 0xb0, 0x79, 0xdc, 0x1f, 0x74,
+	0xb5, 0xa2, 0xf1, 0xb7, 0x02, 0x94, 0x68, 0x10, 0x47, 0x8c, 0xec, 0x40, 0xc9, 0x9e, 0x31, 0xfb,
+	0x5c, 0xad, 0x5e, 0x0e, 0xc8, 0xe7, 0x50, 0x0d, 0xd9, 0x05, 0xe3, 0x6e, 0x74, 0xa9, 0xb6, 0xb0,
+	0x9d, 0x6e, 0x61, 0xa4, 0x04, 0x34, 0xa5, 0x90, 0xcf, 0xa1, 0x6c, 0xd9, 0x91, 0x1b, 0xf8, 0xb8,
+	0x81, 0xd6, 0xc1, 0xad, 0x94, 0x8c, 0x46, 0xf6, 0x3b, 0x28, 0xa4, 0x8a, 0x44, 0x3e, 0x84, 0x9a,
+	0xaf, 0xce, 0x21, 0xd4, 0x37, 0x71, 0x27, 0x19, 0x60, 0x3c, 0x86, 0xb2, 0xe4, 0x13, 0x80, 0xf2,
+	0xf0, 0x64, 0xdc, 0x3f, 0xfe, 0x59, 0xbb, 0x21, 0xbe, 0x8f, 0xfa, 0xbd, 0xee, 0x68, 0xac, 0x15,
+	0x48, 0x05, 0x8a, 0x83, 0x93, 0x9e, 0xb6, 0x61, 0xfc, 0xa9, 0x00, 0xd5, 0x5e, 0xf0, 0x2a, 0xf0,
+	0x5c, 0xfb, 0x92, 0x7c, 0x0c, 0xf5, 0xb9, 0xeb, 0x9b, 0x17, 0x8c, 0x87, 0x62, 0x25, 0x72, 0x3f,
+	0x30, 0x77, 0xfd, 0x1f, 0x25, 0x42, 0x1e, 0x83, 0x66, 0x79, 0x5e, 0xf0, 0x86, 0x39, 0x09, 0x29,
+	0xd4, 0x37, 0xd0, 0xfa, 0x96, 0xc2, 0x15, 0x33, 0x24, 0x9f, 0xc1, 0xf6, 0xdc, 0x8a, 0xec, 0x99,
+	0x39, 0x89, 0x5d, 0xcf, 0x09, 0x19, 0xbf, 0x60, 0x1c, 0xf7, 0x56, 0xa5, 0x1a, 0x0a, 0x9e, 0x67,
+	0xb8, 0xf1, 0x97, 0x22, 0x6c, 0xd2, 0xd8, 0x63, 0x6b, 0x1d, 0x41, 0x87, 0x8a, 0xa0, 0xb9, 0xb6,
+	0xf4, 0x85, 0x1a, 0x4d, 0x86, 0x44, 0x83, 0xe2, 0x39, 0xbb, 0x54, 0x57, 0x2e, 0x3e, 0xc9, 0x63,
+	0x28, 0x9d, 0xba, 0xcc, 0x73, 0xf0, 0xbe, 0x5b, 0x07, 0x37, 0xb3, 0x53, 0x8c, 0x3d, 0xb6, 0x7f,
+	0x2c, 0x44, 0x54, 0x32, 0xc8, 0x53, 0x00, 0x3b, 0x98, 0x2f, 0x2c, 0x6e, 0x45, 0x01, 0xd7, 0x4b,
+	0xc8, 0xd7, 0x97, 0xf9, 0x87, 0xa9, 0x9c, 0xe6, 0xb8, 0xe2, 0xf0, 0xa3, 0x19, 0x67, 0xe1, 0x2c,
+	0xf0, 0x1c, 0xbd, 0xbc, 0x5b, 0x78, 0x54, 0xa0, 0x19, 0x40, 0x3e, 0x85, 0x56, 0xc4, 0xde, 0x46,
+	0x66, 0x46, 0xa9, 0xe0, 0xfa, 0x9a, 0x02, 0x1d, 0xa7, 0xb4, 0x4f, 0xa0, 0x71, 0x1a, 0x70, 0xd3,
+	0x89, 0xb9, 0x85, 0xd7, 0x5e, 0xdd, 0x2d, 0x3c, 0x2a, 0xd2, 0xfa, 0x69, 0xc0, 0x8f, 0x14, 0xb4,
+	0xe4, 0x42, 0xb5, 0xf7, 0xba, 0x90, 0xb1, 0x07, 0x25, 0xdc, 0xa0, 0x70, 0xde, 0x1f, 0x3b, 0x03,
+	0xf4, 0xe3, 0x06, 0x54, 0x8f, 0x69, 0xe7, 0x70, 0xdc, 0x3f, 0x19, 0x6a, 0x05, 0xe1, 0xbd, 0xe3,
+	0xee, 0x6f, 0xc6, 0xda, 0x86, 0xd1, 0x03, 0xc8, 0x36, 0x47, 0x34, 0x68, 0xf4, 0x68, 0xb7, 0x33,
+	0xee, 0x52, 0x73, 0xfc, 0xa2, 0x33, 0xd4, 0x6e, 0x90, 0x26, 0xd4, 0x06, 0xdd, 0xd1, 0x48, 0x0e,
+	0x0b, 0x18, 0x0e, 0x3f, 0xbc, 0xee, 0x0c, 0xb4, 0x0d, 0x21, 0x19, 0x9e, 0x8c, 0x4d, 0x39, 0x2c,
+	0x1a, 0xff, 0xac, 0x41, 0xf9, 0x30, 0xf0, 0x4f, 0xdd, 0x29, 0xf9, 0x3f, 0xd8, 0x51, 0x4e, 0x61,
+	0x4e, 0xb9, 0x65, 0x33, 0x73, 0xc1, 0xb8, 0x1b, 0x38, 0x78, 0x97, 0x45, 0x4a, 0x94, 0xac, 0x27,
+	0x44, 0xaf, 0x50, 0x42, 0x5e, 0xc1, 0xf6, 0x59, 0x30, 0x59, 0x62, 0x4b, 0x7f, 0xaa, 0x1f, 0xdc,
+	0x4f, 0x77, 0x2a, 0xb5, 0xef, 0x7f, 0x17, 0x4c, 0x72, 0x53, 0xc3, 0xae, 0x1f, 0xf1, 0x4b, 0xba,
+	0x75, 0xb6, 0x8c, 0x92, 0x7d, 0xa8, 0x4d, 0x03, 0x73, 0x81, 0xee, 0x8c, 0x7e, 0x51, 0xcf, 0x9d,
+	0x59, 0xe2, 0xe7, 0xb4, 0x3a, 0x55, 0x5f, 0xe4, 0x1e, 0x94, 0x78, 0xec, 0x31, 0x19, 0x43, 0xf5,
+	0x83, 0xe6, 0xd2, 0xfd, 0x53, 0x29, 0x23, 0xdf, 0xc0, 0x5d, 0x3b, 0xf0, 0xed, 0x98, 0x73, 0xe6,
+	0x47, 0xca, 0x9f, 0x73, 0xd7, 0x5b, 0xc2, 0xfd, 0x7d, 0x90, 0x51, 0xa4, 0x67, 0x67, 0x57, 0xfd,
+	0x14, 0xf4, 0xab, 0xf3, 0xdf, 0xb8, 0xbe, 0x13, 0xbc, 0x41, 0xf7, 0x29, 0xd1, 0xdb, 0xab, 0x93,
+	0x7f, 0x42, 0x29, 0x79, 0x08, 0x5b, 0x8e, 0x1b, 0xda, 0xc1, 0x05, 0xe3, 0x97, 0x66, 0xc8, 0x98,
+	0x13, 0xea, 0x15, 0x0c, 0xb7, 0x56, 0x0a, 0x8f, 0x04, 0x2a, 0xa2, 0x8d, 0x33, 0x4c, 0x00, 0x97,
+	0xa6, 0xeb, 0x47, 0x8c, 0x5f, 0x58, 0x9e, 0x72, 0x29, 0x2d, 0x11, 0xf4, 0x15, 0x4e, 0x1e, 0x40,
+	0x99, 0x8b, 0xa4, 0x12, 0xea, 0x35, 0xdc, 0x75, 0x6b, 0x39, 0xd7, 0x50, 0x25, 0x95, 0xd6, 0xa7,
+	0x2c, 0x8c, 0x32, 0x95, 0x80, 0x2a, 0x5b, 0x12, 0x4e, 0x15, 0x3e, 0xc9, 0x67, 0xa3, 0xfa, 0x6e,
+	0x71, 0xe9, 0xd4, 0x93, 0x7c, 0x9d, 0x4b, 0x50, 0xe4, 0x01, 0x6c, 0x85, 0x91, 0xe5, 0x31, 0x13,
+	0x49, 0xa6, 0x35, 0x65, 0x7a, 0x03, 0x35, 0x37, 0x11, 0xee, 0x08, 0xb4, 0x33, 0x65, 0x62, 0x05,
+	0x33, 0x66, 0x79, 0xd1, 0xcc, 0x9c, 0xc7, 0x5e, 0xe4, 0x2e, 0x3c, 0xa6, 0x37, 0xf1, 0xc0, 0x5a,
+	0x12, 0x7e, 0xa9, 0x50, 0x72, 0x0f, 0x9a, 0x33, 0x66, 0xf1, 0x68, 0xc2, 0xac, 0xc8, 0x8c, 0xb9,
+	0xa7, 0xb7, 0x30, 0xe6, 0x1a, 0x29, 0xf8, 0x9a, 0x7b, 0x84, 0xc2, 0x36, 0x7b, 0xbb, 0x60, 0x76,
+	0xc4, 0x1c, 0x93, 0xb3, 0x85, 0xe7, 0xda, 0x56, 0xa8, 0x6f, 0xe1, 0x72, 0x3f, 0x5d, 0x75, 0xb7,
+	0xae, 0x22, 0x52, 0xc5, 0x93, 0xfe, 0xa6, 0xb1, 0x15, 0x98, 0xec, 0xc3, 0x4d, 0x9b, 0x5b, 0xe1,
+	0xcc, 0xf4, 0x82, 0x60, 0x61, 0x72, 0x16, 0x46, 0x16, 0x8f, 0x42, 0x5d, 0xc3, 0x55, 0x6e, 0xa3,
+	0x68, 0x10, 0x04, 0x0b, 0xaa, 0x04, 0x64, 0x0f, 0xb6, 0x73, 0x7c, 0xe5, 0x04, 0xdb, 0xb8, 0xf7,
+	0xad, 0x94, 0xad, 0x6e, 0xff, 0x0b, 0xb8, 0x1d, 0x46, 0xb1, 0x7d, 0x6e, 0xae, 0x06, 0x89, 0x4e,
+	0x70, 0xc2, 0x4d, 0x94, 0x2e, 0x07, 0x86, 0x58, 0xd0, 0xdc, 0x0a, 0x23, 0xc6, 0x97, 0x67, 0xdc,
+	0xc4, 0x19, 0xdb, 0x52, 0x94, 0xe7, 0xdf, 0x83, 0xa6, 0xe2, 0xb3, 0xb7, 0x6c, 0xbe, 0x88, 0xf4,
+	0x1d, 0x74, 0xb0, 0x86, 0x04, 0xbb, 0x88, 0x91, 0xfb, 0xd0, 0xc2, 0xf5, 0x9b, 0x91, 0x3b, 0x67,
+	0xa6, 0xc8, 0xb9, 0xb7, 0xe4, 0xf9, 0x22, 0x3a, 0x76, 0xe7, 0xec, 0x7b, 0x76, 0x29, 0x4c, 0xff,
+	0x21, 0x98, 0x4f, 0x5c, 0xb6, 0x6c, 0xfa, 0xb6, 0x34, 0x2d, 0x45, 0x79, 0xd3, 0x7b, 0xa0, 0x40,
+	0xd3, 0xf6, 0x02, 0xfb, 0xdc, 0x0c, 0xcf, 0xd9, 0x1b, 0xfd, 0x8e, 0x3c, 0x0b, 0x29, 0x38, 0x14,
+	0xf8, 0xe8, 0x9c, 0xbd, 0x69, 0x3f, 0x87, 0x9d, 0x75, 0x19, 0x20, 0x29, 0x01, 0x85, 0xac, 0x04,
+	0xec, 0x40, 0xe9, 0xc2, 0xf2, 0x62, 0x59, 0x2c, 0x8a, 0x54, 0x0e, 0xbe, 0xdc, 0x78, 0x5a, 0x68,
+	0x1f, 0xc2, 0xad, 0xb5, 0xd7, 0xfa, 0x3e, 0x25, 0xa5, 0x9c, 0x12, 0xe3, 0xef, 0x05, 0xa8, 0x8c,
+	0x5c, 0x8f, 0xf9, 0x36, 0x13, 0x8d, 0x84, 0xeb, 0xa8, 0x69, 0x1b, 0xae, 0x93, 0x75, 0x02, 0x1b,
+	0xf9, 0x4e, 0x20, 0x57, 0xbf, 0x8a, 0xcb, 0xf5, 0xeb, 0x23, 0x00, 0xd7, 0x61, 0xbe, 0x8c, 0x0a,
+	0xd5, 0xa2, 0xe4, 0x10, 0x51, 0x8f, 0x3d, 0x6b, 0xc2, 0x3c, 0x93, 0xb3, 0x29, 0x7b, 0x8b, 0x89,
+	0xa6, 0x46, 0x01, 0x21, 0x2a, 0x10, 0x61, 0x10, 0x6f, 0x00, 0xd3, 0x48, 0x91, 0xca, 0x81, 0xd8,
+	0x0e, 0xf3, 0x65, 0xd9, 0x29, 0x52, 0xf1, 0x49, 0x6e, 0x43, 0xd9, 0x8a, 0xa3, 0x59, 0xc0, 0x31,
+	0x27, 0xd4, 0xa8, 0x1a, 0x89, 0xa5, 0xd9, 0xc1, 0x7c, 0xce, 0xfc, 0x08, 0x0b, 0x4c, 0x8d, 0x26,
+	0x43, 0xe3, 0x29, 0x54, 0xd5, 0x2e, 0x43, 0xf2, 0xbf, 0x50, 0x0d, 0xd5, 0xb7, 0x5e, 0xc0, 0x70,
+	0xd1, 0xb2, 0x3a, 0x24, 0x05, 0x34, 0x65, 0x18, 0x67, 0x00, 0x18, 0xbf, 0xdd, 0x0b, 0xe6, 0x47,
+	0x58, 0x2b, 0xdd, 0xb9, 0xf0, 0xfe, 0xf9, 0x42, 0x55, 0x82, 0x0c, 0x10, 0xe5, 0x7e, 0x12, 0x38,
+	0x97, 0xea, 0xbc, 0xf0, 0x9b, 0xec, 0xe1, 0x9e, 0x22, 0xa6, 0x1a, 0xa1, 0x9d, 0xd4, 0x14, 0x6a,
+	0xdd, 0x1f, 0x09, 0x19, 0x95, 0x14, 0xe3, 0x5f, 0x25, 0x28, 0x21, 0xbc, 0xfe, 0x0a, 0x23, 0x37,
+	0xf2, 0x92, 0xa6, 0x41, 0x0e, 0x52, 0x8b, 0xc5, 0x9c, 0x45, 0x51, 0x8a, 0x5d, 0x1e, 0x46, 0x26,
+	0xb7, 0xdc, 0x90, 0xc9, 0xde, 0x41, 0x94, 0x62, 0x81, 0x51, 0x84, 0xe4, 0x4d, 0x64, 0x0c, 0x99,
+	0xf2, 0xc1, 0xb3, 0x52, 0x82, 0xb8, 0xfa, 0x20, 0xf6, 0x23, 0x95, 0xd0, 0xe5, 0x80, 0x7c, 0x0e,
+	0x95, 0x99, 0x1b, 0x46, 0x01, 0xbf, 0xc4, 0xbc, 0x5d, 0xcf, 0x35, 0x24, 0xd9, 0x19, 0xd1, 0x84,
+	0x93, 0xf9, 0x4f, 0x75, 0xd5, 0x7f, 0xe2, 0xc9, 0x19, 0xb3, 0xd3, 0x4b, 0x52, 0xc3, 0xec, 0xa8,
+	0xe0, 0xbd, 0x47, 0x25, 0x2a, 0x84, 0x65, 0x9f, 0xfb, 0xc1, 0x1b, 0x8f, 0x39, 0x53, 0xe6, 0x60,
+	0x24, 0xeb, 0x75, 0x59, 0x21, 0xf2, 0x02, 0x11, 0xcc, 0x22, 0x29, 0x70, 0x16, 0x06, 0xde, 0x45,
+	0x42, 0x94, 0xd9, 0xb9, 0x91, 0x80, 0x09, 0x09, 0xcf, 0x44, 0xa5, 0x75, 0x07, 0x53, 0x73, 0x91,
+	0x36, 0x04, 0xa8, 0x92, 0xbe, 0x23, 0x5c, 0x3c, 0x8c, 0x17, 0x0b, 0xce, 0x42, 0x71, 0x6e, 0x2d,
+	0x3c, 0x9c, 0x1c, 0xb2, 0xd4, 0xe3, 0x6c, 0xbd, 0xbf, 0x4d, 0x3e, 0x80, 0x32, 0xba, 0xbf, 0xc8,
+	0xb0, 0xe2, 0x3c, 0xdb, 0x2b, 0x5b, 0x1e, 0xa0, 0x50, 0x26, 0x6b, 0xc5, 0x24, 0xed, 0xd4, 0x7d,
+	0x1d, 0xcc, 0xb4, 0xd5, 0xd4, 0x59, 0xb1, 0x0b, 0x73, 0xc3, 0x30, 0x66, 0xa6, 0x1f, 0xcf, 0x27,
+	0x8c, 0x63, 0x62, 0x2d, 0xd1, 0x3a, 0x62, 0x43, 0x84, 0x84, 0xc7, 0x44, 0x56, 0x78, 0x8e, 0x19,
+	0xb4, 0x46, 0xf1, 0xbb, 0xfd, 0x2b, 0xa8, 0xe7, 0x2c, 0xbd, 0x2f, 0x7f, 0xd4, 0xf2, 0xf9, 0xe3,
+	0x19, 0x94, 0xf0, 0x5e, 0xc4, 0xef, 0x8a, 0xd7, 0xc3, 0xef, 0x87, 0x27, 0x3f, 0x0d, 0x65, 0x6f,
+	0x7e, 0xdc, 0xa7, 0xfd, 0x61, 0x4f, 0x2b, 0x88, 0x6e, 0xac, 0x73, 0x28, 0x04, 0x83, 0xee, 0x51,
+	0xaf, 0x7b, 0xa4, 0x6d, 0x88, 0x2e, 0x8e, 0x76, 0x47, 0x27, 0x83, 0x1f, 0xbb, 0x47, 0x5a, 0xd1,
+	0xf8, 0x47, 0x0d, 0x1a, 0x1d, 0xb9, 0x6b, 0xa9, 0xe9, 0x01, 0x94, 0xf1, 0x14, 0x92, 0xe8, 0x6c,
+	0x2d, 0x1f, 0x0a, 0x55, 0x52, 0xf2, 0x33, 0x10, 0xbc, 0xb0, 0xb9, 0x1b, 0xca, 0xd6, 0x1c, 0xaf,
+	0x56, 0xf6, 0x5b, 0x9f, 0x2d, 0xcf, 0x51, 0xaa, 0xf7, 0x07, 0x56, 0x18, 0xbd, 0x54, 0x74, 0x71,
+	0xe9, 0xaa, 0x0c, 0x7a, 0x2b, 0x30, 0xf9, 0x1a, 0xaa, 0x33, 0x77, 0x3a, 0x33, 0xed, 0x45, 0xac,
+	0x17, 0x51, 0xa1, 0xb1, 0x5e, 0xe1, 0x0b, 0x77, 0x3a, 0x3b, 0x5c, 0xc4, 0x52, 0x4f, 0x65, 0x26,
+	0x47, 0x64, 0x00, 0x4d, 0xd1, 0x6a, 0x99, 0x13, 0xce, 0x2c, 0x7b, 0x96, 0xb6, 0x63, 0x0f, 0xd7,
+	0xeb, 0x10, 0xbd, 0xd9, 0x73, 0xc5, 0x94, 0x8a, 0x1a, 0x3c, 0x07, 0x09, 0xc7, 0xc4, 0x26, 0xcb,
+	0x0c, 0xad, 0xf9, 0x42, 0x34, 0x77, 0xe2, 0xa7, 0x5e, 0x91, 0x36, 0x10, 0x1c, 0x49, 0x8c, 0x9c,
+	0x40, 0x4b, 0xa5, 0x61, 0x13, 0x63, 0x35, 0xd4, 0xcb, 0x68, 0xf3, 0xd1, 0x7a, 0x9b, 0x23, 0xc9,
+	0x3d, 0x44, 0xaa, 0x34, 0xda, 0x0c, 0xf3, 0x18, 0x39, 0x04, 0x10, 0x75, 0x5a, 0x35, 0x00, 0x95,
+	0x95, 0x2e, 0x76, 0x49, 0xd9, 0x77, 0xc1, 0x64, 0x84, 0x34, 0xa9, 0xa8, 0x76, 0x96, 0x8c, 0x49,
+	0x1f, 0x1a, 0x42, 0x49, 0xda, 0x47, 0x54, 0x51, 0xcd, 0x83, 0x77, 0xaa, 0x49, 0xfa, 0x0a, 0xa9,
+	0xa8, 0x7e, 0x96, 0x21, 0xe4, 0x19, 0xd4, 0xe4, 0x7a, 0x62, 0xfb, 0x5c, 0x35, 0x7a, 0xf7, 0x7e,
+	0x61, 0x39, 0xb1, 0x7d, 0x2e, 0x95, 0x54, 0xcf, 0xd4, 0x90, 0x50, 0xd8, 0x52, 0xad, 0xc1, 0x82,
+	0x07, 0x13, 0x8f, 0xcd, 0x43, 0x1d, 0x50, 0xcf, 0xe3, 0xf5, 0x7a, 0x5e, 0x22, 0xf9, 0x95, 0xe2,
+	0x4a, 0x6d, 0xad, 0xf9, 0x12, 0x28, 0x82, 0x51, 0x22, 0xcc, 0xc1, 0x4e, 0xb1, 0x46, 0xd3, 0xb1,
+	0xa8, 0xcf, 0x6b, 0xfd, 0xed, 0x5a, 0x45, 0xfe, 0x4b, 0x68, 0xe4, 0x7d, 0xec, 0x5a, 0x73, 0xbf,
+	0x85, 0xed, 0x2b, 0xbe, 0x75, 0x2d, 0x05, 0xcf, 0x80, 0x5c, 0x75, 0x94, 0xeb, 0xb4, 0x17, 0xed,
+	0xaf, 0xa0, 0xb5, 0xec, 0x1d, 0xd7, 0xb2, 0xff, 0x03, 0x68, 0xab, 0x4e, 0xb1, 0x66, 0xfe, 0xc3,
+	0xfc, 0xfc, 0x7c, 0xab, 0x9e, 0x4c, 0xcc, 0xab, 0xfc, 0x35, 0x34, 0x97, 0xfc, 0xe3, 0x5a, 0xeb,
+	0xe9, 0xc0, 0xcd, 0x35, 0x4e, 0x71, 0x1d, 0x15, 0xc6, 0x9f, 0x0b, 0x50, 0x7b, 0x91, 0x74, 0xf1,
+	0x69, 0x32, 0x2e, 0x64, 0xc9, 0x58, 0xb8, 0x94, 0xeb, 0x87, 0x91, 0xe5, 0xa7, 0x0f, 0x04, 0xe9,
+	0x98, 0xdc, 0x85, 0x9a, 0x2a, 0xed, 0xb1, 0x7c, 0x59, 0x29, 0xd2, 0x2a, 0x02, 0x34, 0xf6, 0xc9,
+	0x07, 0x50, 0x95, 0x45, 0x3d, 0xf6, 0x55, 0xcd, 0xaf, 0x78, 0x96, 0x14, 0x7d, 0x02, 0x58, 0xc6,
+	0xcc, 0x30, 0xb6, 0x6d, 0x16, 0x86, 0xaa, 0xe0, 0x63, 0x0f, 0x30, 0x92, 0x90, 0x34, 0xab, 0x7e,
+	0x16, 0xc9, 0xf6, 0x2b, 0x1d, 0x1b, 0xbb, 0x50, 0x4d, 0xe3, 0x10, 0xfb, 0x90, 0xb9, 0x6a, 0x9d,
+	0x8a, 0x54, 0x0e, 0x8c, 0x3f, 0x16, 0x00, 0x8e, 0xb9, 0xcb, 0x7c, 0x67, 0x18, 0x38, 0x4b, 0x0f,
+	0x58, 0x85, 0xe5, 0x07, 0x2c, 0x1d, 0x2a, 0xa7, 0xc8, 0x4b, 0x5e, 0x5a, 0x92, 0x21, 0xd9, 0x85,
+	0x7a, 0xec, 0xa3, 0xab, 0x5a, 0x13, 0x8f, 0xa9, 0xb7, 0x95, 0x3c, 0x24, 0x9a, 0x2f, 0xf1, 0x6c,
+	0x11, 0xf8, 0xcc, 0x8f, 0x70, 0x87, 0x25, 0x9a, 0x01, 0xc6, 0x10, 0xea, 0x72, 0x05, 0x3d, 0x6e,
+	0x2d, 0x66, 0xe2, 0xe9, 0xc4, 0x0f, 0x9c, 0xb4, 0xc5, 0xcb, 0x3a, 0x95, 0x6c, 0x99, 0x54, 0x32,
+	0xb0, 0xed, 0x64, 0x2c, 0x5d, 0x91, 0x1c, 0x18, 0xdf, 0xc0, 0xf6, 0xc0, 0x0d, 0x23, 0x4c, 0x09,
+	0x21, 0x65, 0xbf, 0x8f, 0x59, 0x18, 0x89, 0x17, 0x23, 0xd7, 0xb7, 0xbd, 0xd8, 0x61, 0x66, 0xd2,
+	0x3c, 0xe0, 0x0e, 0xab, 0x74, 0x4b, 0xe1, 0x54, 0xc1, 0xc6, 0x57, 0x40, 0xf2, 0xf3, 0xc3, 0x45,
+	0xe0, 0x87, 0xff, 0x75, 0x71, 0x33, 0xee, 0xc1, 0x56, 0x8f, 0xc9, 0xc9, 0x89, 0xed, 0x2b, 0x6e,
+	0x66, 0x3c, 0x05, 0x2d, 0x23, 0x29, 0x03, 0xf7, 0xa1, 0x84, 0x2a, 0x90, 0x77, 0x55, 0xbf, 0x14,
+	0x1a, 0x9f, 0xc1, 0x9d, 0x4e, 0xd6, 0x25, 0xbd, 0xc7, 0xcc, 0x33, 0xd0, 0xaf, 0x92, 0xaf, 0x65,
+	0xee, 0x5b, 0xd8, 0xee, 0x38, 0x4e, 0xd2, 0x5c, 0x2b, 0x43, 0x7b, 0x50, 0x51, 0x8d, 0x8b, 0x9a,
+	0x7c, 0xb5, 0x0d, 0x4f, 0x08, 0xc6, 0x33, 0x20, 0x79, 0x05, 0xca, 0xf8, 0x75, 0x34, 0xdc, 0x82,
+	0x9b, 0xe2, 0x3a, 0x14, 0x9e, 0x5c, 0xa8, 0x71, 0x04, 0x3b, 0xcb, 0xb0, 0x52, 0x7d, 0xbd, 0x1f,
+	0x09, 0x0f, 0x60, 0xe7, 0x88, 0x79, 0x2c, 0x62, 0x2b, 0x5b, 0x5c, 0xf9, 0x45, 0x65, 0xdc, 0x81,
+	0x5b, 0x2b, 0x3c, 0x69, 0xce, 0xb8, 0x0f, 0x2d, 0x1a, 0xfb, 0x63, 0x2b, 0x3c, 0x4f, 0xa6, 0xae,
+	0x79, 0x3a, 0x34, 0xfe, 0x1f, 0xb6, 0x52, 0x96, 0x5a, 0xa7, 0x01, 0x4d, 0x5f, 0x3c, 0xcf, 0xf1,
+	0xd8, 0x97, 0xfd, 0x8f, 0xfc, 0x51, 0x52, 0x17, 0xa0, 0xe0, 0xba, 0x73, 0x66, 0x84, 0x70, 0xab,
+	0xc7, 0xa2, 0x5c, 0x70, 0x24, 0x36, 0xbe, 0x86, 0xf2, 0x69, 0xc0, 0xe7, 0x96, 0xbc, 0xbd, 0x56,
+	0xee, 0xd9, 0x60, 0x2d, 0x7f, 0xff, 0x18, 0xc9, 0x54, 0x4d, 0x32, 0xee, 0x42, 0x59, 0x22, 0xe2,
+	0x25, 0xee, 0xbb, 0xd1, 0x89, 0xe8, 0xfc, 0x2a, 0x50, 0x3c, 0x3a, 0x19, 0x6b, 0x05, 0xe3, 0x77,
+	0x70, 0x7b, 0x55, 0x49, 0x7a, 0x6b, 0xa5, 0xa9, 0x00, 0xd4, 0x9d, 0xed, 0xac, 0x44, 0xa6, 0x24,
+	0x4b, 0x8a, 0xc8, 0x4a, 0x9c, 0xf9, 0x0e, 0xd6, 0x57, 0x95, 0x0c, 0x93, 0xf1, 0xde, 0x97, 0x50,
+	0x4d, 0x5a, 0x6a, 0xf1, 0xa2, 0xf7, 0x7a, 0x38, 0xea, 0x8e, 0xb5, 0x1b, 0x62, 0x2d, 0xfd, 0xe1,
+	0xf1, 0x89, 0x7a, 0xea, 0xee, 0xd0, 0xa1, 0x68, 0x43, 0xb1, 0xe9, 0x3c, 0xa4, 0xfd, 0x71, 0xff,
+	0x50, 0xbc, 0xf3, 0x1d, 0xfc, 0x7b, 0x13, 0x5a, 0x49, 0xb1, 0x57, 0xbf, 0x5e, 0x7b, 0x00, 0x59,
+	0xbc, 0x92, 0xac, 0x13, 0xbf, 0x92, 0x04, 0xda, 0x77, 0xd7, 0xca, 0xd4, 0x4d, 0xde, 0x20, 0x1d,
+	0xa8, 0x26, 0x51, 0x49, 0xf4, 0xfc, 0x89, 0xe6, 0xc3, 0xac, 0xfd, 0xc1, 0x1a, 0x49, 0xaa, 0xe2,
+	0x67, 0xd0, 0x56, 0x23, 0x8e, 0xec, 0x66, 0xa1, 0xb5, 0x3e, 0x72, 0xdb, 0x9f, 0xfc, 0x02, 0x23,
+	0x55, 0xfd, 0x0d, 0x54, 0x94, 0x0f, 0x91, 0x3b, 0xb9, 0xe7, 0xc1, 0xbc, 0xef, 0xb5, 0xf5, 0xab,
+	0x82, 0x74, 0x7e, 0x0f, 0x20, 0x8b, 0xc4, 0xdc, 0x31, 0x5d, 0x89, 0xef, 0xf6, 0xdd, 0xb5, 0xb2,
+	0x54, 0xd1, 0x4b, 0x68, 0xe4, 0x23, 0x8f, 0x7c, 0xb8, 0x74, 0xaa, 0x2b, 0x71, 0xda, 0xfe, 0x9f,
+	0x77, 0x48, 0x53, 0x75, 0xaf, 0xa0, 0xb9, 0x14, 0x5a, 0x24, 0x9b, 0xb1, 0x2e, 0x34, 0xdb, 0x1f,
+	0xbd, 0x4b, 0x9c, 0x6a, 0x1c, 0x41, 0x6b, 0xd9, 0x83, 0xc9, 0x47, 0xbf, 0x1c, 0x1f, 0xed, 0x8f,
+	0xdf, 0x29, 0x4f, 0x94, 0x3e, 0x7f, 0xf8, 0xdb, 0x4f, 0xa7, 0x6e, 0x34, 0x8b, 0x27, 0xfb, 0x76,
+	0x30, 0x7f, 0x32, 0xe1, 0x41, 0x34, 0x63, 0xdc, 0x0b, 0xa6, 0xae, 0xfd, 0x44, 0xcd, 0x7d, 0x82,
+	0xff, 0x59, 0x9a, 0x94, 0xf1, 0xcf, 0x17, 0xff, 0x19, 0x00, 0xdb, 0x12, 0x3b, 0xd6, 0x71, 0x1a,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AlerterServiceClient is the client API for AlerterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AlerterServiceClient interface {
+	ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error)
+	GetAlert(ctx context.Context, in *GetAlertRequest, opts ...grpc.CallOption) (*GetAlertResponse, error)
+	AcknowledgeAlert(ctx context.Context, in *AcknowledgeAlertRequest, opts ...grpc.CallOption) (*AcknowledgeAlertResponse, error)
+	// Runs the task here and now on the master, without taking its lock, so it can
+	// overlap a scheduled run of the same task on another alerter
+	RunTask(ctx context.Context, in *RunTaskRequest, opts ...grpc.CallOption) (*RunTaskResponse, error)
+	AddSilence(ctx context.Context, in *AddSilenceRequest, opts ...grpc.CallOption) (*AddSilenceResponse, error)
+	ListSilences(ctx context.Context, in *ListSilencesRequest, opts ...grpc.CallOption) (*ListSilencesResponse, error)
+	DeleteSilence(ctx context.Context, in *DeleteSilenceRequest, opts ...grpc.CallOption) (*DeleteSilenceResponse, error)
+	GetFriendGraph(ctx context.Context, in *GetFriendGraphRequest, opts ...grpc.CallOption) (*GetFriendGraphResponse, error)
+}
+
+type alerterServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAlerterServiceClient(cc *grpc.ClientConn) AlerterServiceClient {
+	return &alerterServiceClient{cc}
+}
+
+func (c *alerterServiceClient) ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error) {
+	out := new(ListAlertsResponse)
+	err := c.cc.Invoke(ctx, "/alerter.AlerterService/ListAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alerterServiceClient) GetAlert(ctx context.Context, in *GetAlertRequest, opts ...grpc.CallOption) (*GetAlertResponse, error) {
+	out := new(GetAlertResponse)
+	err := c.cc.Invoke(ctx, "/alerter.AlerterService/GetAlert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alerterServiceClient) AcknowledgeAlert(ctx context.Context, in *AcknowledgeAlertRequest, opts ...grpc.CallOption) (*AcknowledgeAlertResponse, error) {
+	out := new(AcknowledgeAlertResponse)
+	err := c.cc.Invoke(ctx, "/alerter.AlerterService/AcknowledgeAlert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alerterServiceClient) RunTask(ctx context.Context, in *RunTaskRequest, opts ...grpc.CallOption) (*RunTaskResponse, error) {
+	out := new(RunTaskResponse)
+	err := c.cc.Invoke(ctx, "/alerter.AlerterService/RunTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alerterServiceClient) AddSilence(ctx context.Context, in *AddSilenceRequest, opts ...grpc.CallOption) (*AddSilenceResponse, error) {
+	out := new(AddSilenceResponse)
+	err := c.cc.Invoke(ctx, "/alerter.AlerterService/AddSilence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alerterServiceClient) ListSilences(ctx context.Context, in *ListSilencesRequest, opts ...grpc.CallOption) (*ListSilencesResponse, error) {
+	out := new(ListSilencesResponse)
+	err := c.cc.Invoke(ctx, "/alerter.AlerterService/ListSilences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alerterServiceClient) DeleteSilence(ctx context.Context, in *DeleteSilenceRequest, opts ...grpc.CallOption) (*DeleteSilenceResponse, error) {
+	out := new(DeleteSilenceResponse)
+	err := c.cc.Invoke(ctx, "/alerter.AlerterService/DeleteSilence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alerterServiceClient) GetFriendGraph(ctx context.Context, in *GetFriendGraphRequest, opts ...grpc.CallOption) (*GetFriendGraphResponse, error) {
+	out := new(GetFriendGraphResponse)
+	err := c.cc.Invoke(ctx, "/alerter.AlerterService/GetFriendGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AlerterServiceServer is the server API for AlerterService service.
+type AlerterServiceServer interface {
+	ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error)
+	GetAlert(context.Context, *GetAlertRequest) (*GetAlertResponse, error)
+	AcknowledgeAlert(context.Context, *AcknowledgeAlertRequest) (*AcknowledgeAlertResponse, error)
+	// Runs the task here and now on the master, without taking its lock, so it can
+	// overlap a scheduled run of the same task on another alerter
+	RunTask(context.Context, *RunTaskRequest) (*RunTaskResponse, error)
+	AddSilence(context.Context, *AddSilenceRequest) (*AddSilenceResponse, error)
+	ListSilences(context.Context, *ListSilencesRequest) (*ListSilencesResponse, error)
+	DeleteSilence(context.Context, *DeleteSilenceRequest) (*DeleteSilenceResponse, error)
+	GetFriendGraph(context.Context, *GetFriendGraphRequest) (*GetFriendGraphResponse, error)
+}
+
+// UnimplementedAlerterServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAlerterServiceServer struct {
+}
+
+func (*UnimplementedAlerterServiceServer) ListAlerts(ctx context.Context, req *ListAlertsRequest) (*ListAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlerts not implemented")
+}
+func (*UnimplementedAlerterServiceServer) GetAlert(ctx context.Context, req *GetAlertRequest) (*GetAlertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlert not implemented")
+}
+func (*UnimplementedAlerterServiceServer) AcknowledgeAlert(ctx context.Context, req *AcknowledgeAlertRequest) (*AcknowledgeAlertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeAlert not implemented")
+}
+func (*UnimplementedAlerterServiceServer) RunTask(ctx context.Context, req *RunTaskRequest) (*RunTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunTask not implemented")
+}
+func (*UnimplementedAlerterServiceServer) AddSilence(ctx context.Context, req *AddSilenceRequest) (*AddSilenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSilence not implemented")
+}
+func (*UnimplementedAlerterServiceServer) ListSilences(ctx context.Context, req *ListSilencesRequest) (*ListSilencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSilences not implemented")
+}
+func (*UnimplementedAlerterServiceServer) DeleteSilence(ctx context.Context, req *DeleteSilenceRequest) (*DeleteSilenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSilence not implemented")
+}
+func (*UnimplementedAlerterServiceServer) GetFriendGraph(ctx context.Context, req *GetFriendGraphRequest) (*GetFriendGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFriendGraph not implemented")
+}
+
+func RegisterAlerterServiceServer(s *grpc.Server, srv AlerterServiceServer) {
+	s.RegisterService(&_AlerterService_serviceDesc, srv)
+}
+
+func _AlerterService_ListAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlerterServiceServer).ListAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alerter.AlerterService/ListAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlerterServiceServer).ListAlerts(ctx, req.(*ListAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlerterService_GetAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlerterServiceServer).GetAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alerter.AlerterService/GetAlert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlerterServiceServer).GetAlert(ctx, req.(*GetAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlerterService_AcknowledgeAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlerterServiceServer).AcknowledgeAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alerter.AlerterService/AcknowledgeAlert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlerterServiceServer).AcknowledgeAlert(ctx, req.(*AcknowledgeAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlerterService_RunTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlerterServiceServer).RunTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alerter.AlerterService/RunTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlerterServiceServer).RunTask(ctx, req.(*RunTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlerterService_AddSilence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSilenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlerterServiceServer).AddSilence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alerter.AlerterService/AddSilence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlerterServiceServer).AddSilence(ctx, req.(*AddSilenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlerterService_ListSilences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSilencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlerterServiceServer).ListSilences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alerter.AlerterService/ListSilences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlerterServiceServer).ListSilences(ctx, req.(*ListSilencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlerterService_DeleteSilence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSilenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlerterServiceServer).DeleteSilence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alerter.AlerterService/DeleteSilence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlerterServiceServer).DeleteSilence(ctx, req.(*DeleteSilenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlerterService_GetFriendGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFriendGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlerterServiceServer).GetFriendGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alerter.AlerterService/GetFriendGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlerterServiceServer).GetFriendGraph(ctx, req.(*GetFriendGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AlerterService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "alerter.AlerterService",
+	HandlerType: (*AlerterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAlerts",
+			Handler:    _AlerterService_ListAlerts_Handler,
+		},
+		{
+			MethodName: "GetAlert",
+			Handler:    _AlerterService_GetAlert_Handler,
+		},
+		{
+			MethodName: "AcknowledgeAlert",
+			Handler:    _AlerterService_AcknowledgeAlert_Handler,
+		},
+		{
+			MethodName: "RunTask",
+			Handler:    _AlerterService_RunTask_Handler,
+		},
+		{
+			MethodName: "AddSilence",
+			Handler:    _AlerterService_AddSilence_Handler,
+		},
+		{
+			MethodName: "ListSilences",
+			Handler:    _AlerterService_ListSilences_Handler,
+		},
+		{
+			MethodName: "DeleteSilence",
+			Handler:    _AlerterService_DeleteSilence_Handler,
+		},
+		{
+			MethodName: "GetFriendGraph",
+			Handler:    _AlerterService_GetFriendGraph_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "alerter.proto",
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+// severity defaults unset severities to warnings
+func severity(alert *pb.Alert) pb.Severity {
+	if alert.GetSeverity() == pb.Severity_UNSET {
+		return pb.Severity_WARNING
+	}
+	return alert.GetSeverity()
+}
+
+// route decides what to do with an alert, falling back to issues for anything above info.
+// A route without a check or severity matches any check or severity.
+func (s *Server) route(alert *pb.Alert) *pb.Route {
+	for _, route := range s.getConfig().GetRoutes() {
+		if (len(route.GetCheck()) == 0 || route.GetCheck() == alert.GetCheck()) && (route.GetSeverity() == pb.Severity_UNSET || route.GetSeverity() == severity(alert)) {
+			return route
+		}
+	}
+
+	if severity(alert) == pb.Severity_INFO {
+		return &pb.Route{Action: pb.Route_DIGEST}
+	}
+	return &pb.Route{Action: pb.Route_NOTIFY}
+}
+
+// sendDigest raises a single issue covering everything routed to the digest
+func (s *Server) sendDigest(ctx context.Context) (time.Time, error) {
+	s.digestMutex.Lock()
+	digest := s.digest
+	s.digest = []string{}
+	s.digestMutex.Unlock()
+
+	if len(digest) > 0 {
+		s.RaiseIssue(ctx, "Alerter Digest", strings.Join(digest, "\n"), false)
+	}
+
+	return time.Now().Add(time.Duration(s.getConfig().GetDigestInterval()) * time.Second), nil
+}
+
+func (s *Server) addToDigest(alert *pb.Alert) {
+	s.digestMutex.Lock()
+	defer s.digestMutex.Unlock()
+	s.digest = append(s.digest, fmt.Sprintf("[%v] %v: %v", severity(alert), alert.GetTitle(), alert.GetBody()))
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+func TestDefaultRoutes(t *testing.T) {
+	s := InitTestServer()
+
+	if s.route(&pb.Alert{Severity: pb.Severity_CRITICAL}).GetAction() != pb.Route_NOTIFY {
+		t.Errorf("Critical alert was not routed to an issue")
+	}
+	if s.route(&pb.Alert{}).GetAction() != pb.Route_NOTIFY {
+		t.Errorf("Unset alert was not routed to an issue")
+	}
+	if s.route(&pb.Alert{Severity: pb.Severity_INFO}).GetAction() != pb.Route_DIGEST {
+		t.Errorf("Info alert was not routed to the digest")
+	}
+}
+
+func TestConfiguredRoutes(t *testing.T) {
+	s := InitTestServer()
+	s.config.Routes = []*pb.Route{
+		&pb.Route{Check: "check_friends", Severity: pb.Severity_WARNING, Action: pb.Route_LOG},
+		&pb.Route{Severity: pb.Severity_WARNING, Action: pb.Route_DIGEST},
+	}
+
+	if s.route(&pb.Alert{Check: "check_friends", Severity: pb.Severity_WARNING}).GetAction() != pb.Route_LOG {
+		t.Errorf("Check route was not used")
+	}
+	if s.route(&pb.Alert{Check: "evaluate_friends", Severity: pb.Severity_WARNING}).GetAction() != pb.Route_DIGEST {
+		t.Errorf("Severity route was not used")
+	}
+	if s.route(&pb.Alert{Check: "evaluate_friends", Severity: pb.Severity_CRITICAL}).GetAction() != pb.Route_NOTIFY {
+		t.Errorf("Default route was not used")
+	}
+}
+
+func TestRouteAnySeverity(t *testing.T) {
+	s := InitTestServer()
+	s.config.Routes = []*pb.Route{&pb.Route{Check: "check_friends", Action: pb.Route_LOG}}
+
+	if s.route(&pb.Alert{Check: "check_friends", Severity: pb.Severity_CRITICAL}).GetAction() != pb.Route_LOG {
+		t.Errorf("Route without a severity was not used")
+	}
+	if s.route(&pb.Alert{Check: "check_friends"}).GetAction() != pb.Route_LOG {
+		t.Errorf("Route without a severity was not used for an unset alert")
+	}
+	if s.route(&pb.Alert{Check: "evaluate_friends", Severity: pb.Severity_CRITICAL}).GetAction() != pb.Route_NOTIFY {
+		t.Errorf("Route was used for the wrong check")
+	}
+}
+
+func TestDigest(t *testing.T) {
+	s := InitTestServer()
+	s.lookForGoVersion(context.Background())
+
+	if len(s.digest) != 1 {
+		t.Fatalf("Info alert did not reach the digest: %v", s.digest)
+	}
+
+	s.sendDigest(context.Background())
+	if len(s.digest) != 0 {
+		t.Errorf("Digest was not cleared: %v", s.digest)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+	pbg "github.com/brotherlogic/goserver/proto"
+)
+
+// ruleBroken checks the state against the rule, returning the observed value
+func ruleBroken(rule *pb.Rule, state *pbg.State) (bool, string) {
+	if rule.GetField() == pb.Rule_TEXT {
+		switch rule.GetComparator() {
+		case pb.Rule_GREATER_THAN:
+			return state.Text > rule.GetTextThreshold(), state.Text
+		case pb.Rule_LESS_THAN:
+			return state.Text < rule.GetTextThreshold(), state.Text
+		case pb.Rule_EQUAL:
+			return state.Text == rule.GetTextThreshold(), state.Text
+		case pb.Rule_NOT_EQUAL:
+			return state.Text != rule.GetTextThreshold(), state.Text
+		}
+		return false, state.Text
+	}
+
+	value := float64(state.Value)
+	if rule.GetField() == pb.Rule_FRACTION {
+		value = state.Fraction
+	}
+
+	switch rule.GetComparator() {
+	case pb.Rule_GREATER_THAN:
+		return value > rule.GetThreshold(), fmt.Sprintf("%v", value)
+	case pb.Rule_LESS_THAN:
+		return value < rule.GetThreshold(), fmt.Sprintf("%v", value)
+	case pb.Rule_EQUAL:
+		return value == rule.GetThreshold(), fmt.Sprintf("%v", value)
+	case pb.Rule_NOT_EQUAL:
+		return value != rule.GetThreshold(), fmt.Sprintf("%v", value)
+	}
+	return false, fmt.Sprintf("%v", value)
+}
+
+// applyRule checks the rule against the service, returning false if the service does not
+// report the state the rule is on
+func (s *Server) applyRule(ctx context.Context, rule *pb.Rule, service *pbd.RegistryEntry, stats *pbg.ServerState) bool {
+	subject := service.Identifier + service.Name
+	key := fingerprint(rule.GetName(), subject)
+
+	for _, state := range stats.States {
+		if state.Key == rule.GetKey() {
+			broken, _ := ruleBroken(rule, state)
+			if !broken {
+				s.clearBreach(key)
+				s.alerts.pass(ctx, rule.GetName(), subject)
+				return true
+			}
+
+			since := s.breachSince(key)
+			if time.Since(since) >= time.Duration(rule.GetForDuration())*time.Second {
+				threshold := fmt.Sprintf("%v", rule.GetThreshold())
+				if rule.GetField() == pb.Rule_TEXT {
+					threshold = rule.GetTextThreshold()
+				}
+				s.alerts.fire(ctx, &pb.Alert{Check: rule.GetName(), Subject: subject, Labels: labels(service), Severity: rule.GetSeverity(), Title: rule.GetName(),
+					Body: fmt.Sprintf("%v on %v has had %v %v %v since %v", service.Name, service.Identifier, rule.GetKey(), rule.GetComparator(), threshold, since.Format(time.RFC822))})
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateRules runs every configured rule against the state of the services it selects
+func (s *Server) evaluateRules(ctx context.Context) (time.Time, error) {
+	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+
+	// The name is the check of the rule's alerts, so we can't run a rule without one
+	named := []*pb.Rule{}
+	for _, rule := range s.getConfig().GetRules() {
+		if len(rule.GetName()) == 0 {
+			err = status.Errorf(codes.InvalidArgument, "Rule on %v has no name", rule.GetKey())
+			continue
+		}
+		named = append(named, rule)
+	}
+
+	seen := make(map[string]map[string]bool)
+	breaches := make(map[string]bool)
+	for _, rule := range named {
+		seen[rule.GetName()] = make(map[string]bool)
+	}
+
+	for _, service := range serv.Services.Services {
+		rules := []*pb.Rule{}
+		for _, rule := range named {
+			if len(rule.GetService()) == 0 || rule.GetService() == service.Name {
+				rules = append(rules, rule)
+			}
+		}
+
+		if len(rules) > 0 {
+			stats, err := s.goserver.GetStats(ctx, service.Ip, service.Port)
+			if err == nil {
+				s.recordExamined(ctx, 1, 0)
+				for _, rule := range rules {
+					if s.applyRule(ctx, rule, service, stats) {
+						seen[rule.GetName()][service.Identifier+service.Name] = true
+						breaches[fingerprint(rule.GetName(), service.Identifier+service.Name)] = true
+					}
+				}
+			}
+		}
+	}
+
+	for name, subjects := range seen {
+		s.alerts.passUnseen(ctx, name, subjects)
+	}
+	s.pruneBreaches(breaches)
+
+	return time.Now().Add(time.Minute * 5), err
+}
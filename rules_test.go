@@ -0,0 +1,134 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+	pbg "github.com/brotherlogic/goserver/proto"
+)
+
+func TestRuleBroken(t *testing.T) {
+	state := &pbg.State{Key: "test", Value: int64(5), Fraction: float64(0.5), Text: "hello"}
+	var tests = []struct {
+		rule   *pb.Rule
+		broken bool
+	}{
+		{&pb.Rule{Field: pb.Rule_VALUE, Comparator: pb.Rule_GREATER_THAN, Threshold: 4}, true},
+		{&pb.Rule{Field: pb.Rule_VALUE, Comparator: pb.Rule_GREATER_THAN, Threshold: 5}, false},
+		{&pb.Rule{Field: pb.Rule_VALUE, Comparator: pb.Rule_EQUAL, Threshold: 5}, true},
+		{&pb.Rule{Field: pb.Rule_FRACTION, Comparator: pb.Rule_LESS_THAN, Threshold: 0.6}, true},
+		{&pb.Rule{Field: pb.Rule_FRACTION, Comparator: pb.Rule_NOT_EQUAL, Threshold: 0.5}, false},
+		{&pb.Rule{Field: pb.Rule_TEXT, Comparator: pb.Rule_EQUAL, TextThreshold: "hello"}, true},
+		{&pb.Rule{Field: pb.Rule_TEXT, Comparator: pb.Rule_NOT_EQUAL, TextThreshold: "hello"}, false},
+	}
+
+	for _, test := range tests {
+		broken, _ := ruleBroken(test.rule, state)
+		if broken != test.broken {
+			t.Errorf("Rule %v gave %v against %v", test.rule, broken, state)
+		}
+	}
+}
+
+func TestEvaluateRules(t *testing.T) {
+	s := InitTestServer()
+	s.config.Rules = []*pb.Rule{&pb.Rule{Name: "high_cpu", Service: "gobuildslave", Key: "cpu", Field: pb.Rule_FRACTION, Comparator: pb.Rule_GREATER_THAN, Threshold: 40}}
+
+	_, err := s.evaluateRules(context.Background())
+	if err != nil {
+		t.Fatalf("Unable to evaluate rules: %v", err)
+	}
+
+	if _, ok := s.alerts.get("high_cpu:gobuildslave"); !ok {
+		t.Errorf("Rule did not fire")
+	}
+}
+
+func TestEvaluateRulesOtherService(t *testing.T) {
+	s := InitTestServer()
+	s.config.Rules = []*pb.Rule{&pb.Rule{Name: "high_cpu", Service: "buildserver", Key: "cpu", Field: pb.Rule_FRACTION, Comparator: pb.Rule_GREATER_THAN, Threshold: 40}}
+
+	s.evaluateRules(context.Background())
+
+	if alertCount(s) != 0 {
+		t.Errorf("Rule fired on the wrong service: %v", alertCount(s))
+	}
+}
+
+func TestEvaluateRulesForDuration(t *testing.T) {
+	s := InitTestServer()
+	s.config.Rules = []*pb.Rule{&pb.Rule{Name: "high_cpu", Key: "cpu", Field: pb.Rule_FRACTION, Comparator: pb.Rule_GREATER_THAN, Threshold: 40, ForDuration: 60 * 10}}
+
+	s.evaluateRules(context.Background())
+	if alertCount(s) != 0 {
+		t.Fatalf("Rule fired before its duration: %v", alertCount(s))
+	}
+
+	s.ruleBreaches["high_cpu:gobuildslave"] = time.Now().Add(-time.Hour)
+	s.evaluateRules(context.Background())
+	if alertCount(s) != 1 {
+		t.Errorf("Rule did not fire after its duration: %v", alertCount(s))
+	}
+}
+
+func TestEvaluateRulesClears(t *testing.T) {
+	s := InitTestServer()
+	s.config.Rules = []*pb.Rule{&pb.Rule{Name: "high_cpu", Key: "cpu", Field: pb.Rule_FRACTION, Comparator: pb.Rule_GREATER_THAN, Threshold: 40}}
+
+	s.evaluateRules(context.Background())
+	s.goserver = &testGoserver{reportsNormal: true}
+	s.config.Rules[0].Threshold = 400
+	s.evaluateRules(context.Background())
+
+	alert, _ := s.alerts.get("high_cpu:gobuildslave")
+	if alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Rule did not clear: %v", alert)
+	}
+}
+
+func TestEvaluateRulesResolvesWhenServiceLeaves(t *testing.T) {
+	s := InitTestServer()
+	s.config.Rules = []*pb.Rule{&pb.Rule{Name: "high_cpu", Key: "cpu", Field: pb.Rule_FRACTION, Comparator: pb.Rule_GREATER_THAN, Threshold: 40}}
+	s.evaluateRules(context.Background())
+
+	s.discover = &testDiscovery{services: []*pbd.RegistryEntry{}}
+	s.evaluateRules(context.Background())
+
+	if alert, _ := s.alerts.get("high_cpu:gobuildslave"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Rule was not resolved once the service left: %v", alert)
+	}
+	if len(s.ruleBreaches) != 0 {
+		t.Errorf("Breach was kept: %v", s.ruleBreaches)
+	}
+}
+
+func TestRuleAlertStableAsValueMoves(t *testing.T) {
+	s := InitTestServer()
+	s.config.Rules = []*pb.Rule{&pb.Rule{Name: "high_cpu", Key: "cpu", Field: pb.Rule_VALUE, Comparator: pb.Rule_GREATER_THAN, Threshold: 40}}
+
+	for _, value := range []int64{50, 60, 70} {
+		s.goserver = &testGoserver{stats: map[string]*pbg.ServerState{"1234:123": &pbg.ServerState{States: []*pbg.State{&pbg.State{Key: "cpu", Value: value}}}}}
+		s.evaluateRules(context.Background())
+	}
+
+	if alert, _ := s.alerts.get("high_cpu:gobuildslave"); alert.GetSuppressed() != 2 {
+		t.Errorf("Changes in the value renotified: %v", alert)
+	}
+}
+
+func TestEvaluateRulesNeedsName(t *testing.T) {
+	s := InitTestServer()
+	s.config.Rules = []*pb.Rule{&pb.Rule{Key: "cpu", Field: pb.Rule_FRACTION, Comparator: pb.Rule_GREATER_THAN, Threshold: 40}}
+
+	_, err := s.evaluateRules(context.Background())
+	if err == nil {
+		t.Errorf("Unnamed rule was accepted")
+	}
+	if alerts := s.alerts.list(true); len(alerts) != 0 {
+		t.Errorf("Unnamed rule raised alerts: %v", alerts)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+// countServices counts the registry entries for each service name
+func countServices(services []*pbd.RegistryEntry) map[string]int32 {
+	counts := make(map[string]int32)
+	for _, service := range services {
+		counts[service.Name]++
+	}
+	return counts
+}
+
+// takeSnapshot swaps in the latest service counts, returning the previous ones. Services
+// that have vanished stay in the snapshot at zero until they return, unless they are
+// expected to have no replicas.
+func (s *Server) takeSnapshot(counts map[string]int32) map[string]int32 {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+
+	previous := s.serviceCounts
+	s.serviceCounts = make(map[string]int32)
+	for name := range previous {
+		if expected, ok := s.getConfig().GetExpectedReplicas()[name]; !ok || expected > 0 {
+			s.serviceCounts[name] = 0
+		}
+	}
+	for name, count := range counts {
+		s.serviceCounts[name] = count
+	}
+	return previous
+}
+
+// lookForMissingServices diffs the registry against the last snapshot, alerting on services
+// that have gone away or are running fewer replicas than we expect
+func (s *Server) lookForMissingServices(ctx context.Context) (time.Time, error) {
+	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+
+	counts := countServices(serv.GetServices().GetServices())
+	s.recordExamined(ctx, len(serv.GetServices().GetServices()), 0)
+	previous := s.takeSnapshot(counts)
+
+	seen := make(map[string]bool)
+	for name := range previous {
+		if _, ok := counts[name]; !ok {
+			if expected, ok := s.getConfig().GetExpectedReplicas()[name]; ok && expected == 0 {
+				continue
+			}
+			seen[name] = true
+			s.alerts.fire(ctx, &pb.Alert{Check: "missing_service", Subject: name, Labels: map[string]string{"service": name}, Severity: pb.Severity_CRITICAL, Title: "Service Missing",
+				Body: fmt.Sprintf("%v is no longer registered", name)})
+		}
+	}
+
+	for name, expected := range s.getConfig().GetExpectedReplicas() {
+		if counts[name] < expected && !seen[name] {
+			seen[name] = true
+			s.alerts.fire(ctx, &pb.Alert{Check: "missing_service", Subject: name, Labels: map[string]string{"service": name}, Severity: pb.Severity_WARNING, Title: "Service Short",
+				Body: fmt.Sprintf("%v has %v of %v expected replicas", name, counts[name], expected)})
+		}
+	}
+	s.alerts.passUnseen(ctx, "missing_service", seen)
+
+	return time.Now().Add(time.Minute * 5), nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+func TestServiceDisappears(t *testing.T) {
+	s := InitTestServer()
+	d := &testDiscovery{services: []*pbd.RegistryEntry{&pbd.RegistryEntry{Name: "recordcollection"}, &pbd.RegistryEntry{Name: "gobuildslave"}}}
+	s.discover = d
+	s.lookForMissingServices(context.Background())
+
+	d.services = []*pbd.RegistryEntry{&pbd.RegistryEntry{Name: "gobuildslave"}}
+	s.lookForMissingServices(context.Background())
+	s.lookForMissingServices(context.Background())
+
+	alert, ok := s.alerts.get("missing_service:recordcollection")
+	if !ok || alert.GetState() != pb.Alert_FIRING || alert.GetSeverity() != pb.Severity_CRITICAL {
+		t.Fatalf("Missing service was not raised: %v", alert)
+	}
+	if alert.GetSuppressed() != 1 {
+		t.Errorf("Repeat of the missing service was notified again: %v", alert)
+	}
+
+	d.services = []*pbd.RegistryEntry{&pbd.RegistryEntry{Name: "gobuildslave"}, &pbd.RegistryEntry{Name: "recordcollection"}}
+	s.lookForMissingServices(context.Background())
+
+	if alert, _ = s.alerts.get("missing_service:recordcollection"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Missing service was not resolved on return: %v", alert)
+	}
+}
+
+func TestServiceAllowedToLeave(t *testing.T) {
+	s := InitTestServer()
+	s.config.ExpectedReplicas = map[string]int32{"recordcollection": 0}
+	d := &testDiscovery{services: []*pbd.RegistryEntry{&pbd.RegistryEntry{Name: "recordcollection"}}}
+	s.discover = d
+	s.lookForMissingServices(context.Background())
+
+	d.services = []*pbd.RegistryEntry{}
+	s.lookForMissingServices(context.Background())
+
+	if alert, ok := s.alerts.get("missing_service:recordcollection"); ok {
+		t.Errorf("Alerted on a service allowed to leave: %v", alert)
+	}
+	if _, ok := s.serviceCounts["recordcollection"]; ok {
+		t.Errorf("Service was kept in the snapshot: %v", s.serviceCounts)
+	}
+}
+
+func TestServiceShortOfReplicas(t *testing.T) {
+	s := InitTestServer()
+	s.config.ExpectedReplicas = map[string]int32{"gobuildslave": 3}
+	s.lookForMissingServices(context.Background())
+
+	alert, ok := s.alerts.get("missing_service:gobuildslave")
+	if !ok || alert.GetTitle() != "Service Short" {
+		t.Errorf("Short replicas were not raised: %v", alert)
+	}
+}
+
+func TestServiceSnapshotSurvivesFailover(t *testing.T) {
+	s := InitTestServer()
+	s.lookForMissingServices(context.Background())
+	s.saveState(context.Background())
+
+	s.serviceCounts = make(map[string]int32)
+	s.loadState(context.Background())
+
+	if s.serviceCounts["gobuildslave"] != 1 {
+		t.Errorf("Snapshot was not reloaded: %v", s.serviceCounts)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+const (
+	// SILENCES is where we store the silences in keystore
+	SILENCES = "github.com/brotherlogic/alerter/silences"
+
+	// How often every alerter picks up the silences added through the others
+	silenceRefresh = time.Minute
+)
+
+// labels describes the service an alert is about
+func labels(service *pbd.RegistryEntry) map[string]string {
+	return map[string]string{"service": service.Name, "identifier": service.Identifier}
+}
+
+// silenceMatches is true if the silence is active and every matcher it sets matches the alert
+func silenceMatches(silence *pb.Silence, alert *pb.Alert, now time.Time) bool {
+	if now.Unix() < silence.GetStart() || now.Unix() >= silence.GetEnd() {
+		return false
+	}
+
+	if len(silence.GetCheck()) > 0 && silence.GetCheck() != alert.GetCheck() {
+		return false
+	}
+	if len(silence.GetService()) > 0 && silence.GetService() != alert.GetLabels()["service"] {
+		return false
+	}
+	if len(silence.GetIdentifier()) > 0 && silence.GetIdentifier() != alert.GetLabels()["identifier"] {
+		return false
+	}
+
+	if len(silence.GetLabelRegex()) > 0 {
+		reg, err := regexp.Compile(silence.GetLabelRegex())
+		if err != nil {
+			return false
+		}
+		for key, value := range alert.GetLabels() {
+			if reg.MatchString(fmt.Sprintf("%v=%v", key, value)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// silenced is true if any silence currently applies to the alert
+func (s *Server) silenced(alert *pb.Alert) bool {
+	s.silencesMutex.Lock()
+	defer s.silencesMutex.Unlock()
+
+	for _, silence := range s.silences.GetSilences() {
+		if silenceMatches(silence, alert, time.Now()) {
+			return true
+		}
+	}
+	return false
+}
+
+// readSilences reads the stored silences, which are empty if none have been saved yet
+func (s *Server) readSilences(ctx context.Context) (*pb.Silences, error) {
+	data, _, err := s.KSclient.Read(ctx, SILENCES, &pb.Silences{})
+	if err != nil {
+		if code := status.Convert(err).Code(); code == codes.NotFound || code == codes.InvalidArgument {
+			return &pb.Silences{}, nil
+		}
+		return nil, err
+	}
+	return data.(*pb.Silences), nil
+}
+
+// updateSilences applies the change to the stored silences, only taking them on here once
+// they are saved
+func (s *Server) updateSilences(ctx context.Context, change func(silences *pb.Silences) error) error {
+	s.silencesMutex.Lock()
+	defer s.silencesMutex.Unlock()
+
+	silences, err := s.readSilences(ctx)
+	if err != nil {
+		return err
+	}
+	if err := change(silences); err != nil {
+		return err
+	}
+	if err := s.KSclient.Save(ctx, SILENCES, silences); err != nil {
+		return err
+	}
+
+	s.silences = silences
+	return nil
+}
+
+// loadSilences refreshes the silences from keystore
+func (s *Server) loadSilences(ctx context.Context) error {
+	silences, err := s.readSilences(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.silencesMutex.Lock()
+	defer s.silencesMutex.Unlock()
+	s.silences = silences
+	return nil
+}
+
+// refreshSilences keeps every alerter, master or not, up to date with the silences added
+// through the others until the context is done
+func (s *Server) refreshSilences(ctx context.Context) {
+	for {
+		if err := s.loadSilences(ctx); err != nil {
+			s.Log(fmt.Sprintf("Unable to load silences: %v", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(silenceRefresh):
+		}
+	}
+}
+
+func validateSilence(silence *pb.Silence) error {
+	if len(silence.GetCheck()) == 0 && len(silence.GetService()) == 0 && len(silence.GetIdentifier()) == 0 && len(silence.GetLabelRegex()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "A silence must match on something")
+	}
+	if silence.GetEnd() <= silence.GetStart() {
+		return status.Errorf(codes.InvalidArgument, "A silence must end after it starts")
+	}
+	if len(silence.GetAuthor()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "A silence must have an author")
+	}
+	if _, err := regexp.Compile(silence.GetLabelRegex()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Bad label regex: %v", err)
+	}
+	return nil
+}
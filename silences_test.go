@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+func TestSilenceMatches(t *testing.T) {
+	now := time.Now()
+	alert := &pb.Alert{Check: "look_for_go_version", Labels: map[string]string{"service": "gobuildslave", "identifier": "stack1"}}
+	var tests = []struct {
+		silence *pb.Silence
+		matches bool
+	}{
+		{&pb.Silence{Check: "look_for_go_version", End: now.Add(time.Hour).Unix()}, true},
+		{&pb.Silence{Check: "check_friends", End: now.Add(time.Hour).Unix()}, false},
+		{&pb.Silence{Service: "gobuildslave", Identifier: "stack1", End: now.Add(time.Hour).Unix()}, true},
+		{&pb.Silence{Service: "gobuildslave", Identifier: "stack2", End: now.Add(time.Hour).Unix()}, false},
+		{&pb.Silence{LabelRegex: "identifier=stack[0-9]", End: now.Add(time.Hour).Unix()}, true},
+		{&pb.Silence{LabelRegex: "identifier=other", End: now.Add(time.Hour).Unix()}, false},
+		{&pb.Silence{Check: "look_for_go_version", End: now.Add(-time.Hour).Unix()}, false},
+		{&pb.Silence{Check: "look_for_go_version", Start: now.Add(time.Hour).Unix(), End: now.Add(time.Hour * 2).Unix()}, false},
+	}
+
+	for _, test := range tests {
+		if silenceMatches(test.silence, alert, now) != test.matches {
+			t.Errorf("%v against %v should have been %v", test.silence, alert, test.matches)
+		}
+	}
+}
+
+func TestAddSilence(t *testing.T) {
+	s := InitTestServer()
+
+	_, err := s.AddSilence(context.Background(), &pb.AddSilenceRequest{Silence: &pb.Silence{Service: "gobuildslave", End: time.Now().Add(time.Hour).Unix(), Author: "brotherlogic", Comment: "Rebuilding"}})
+	if err != nil {
+		t.Fatalf("Unable to add silence: %v", err)
+	}
+
+	s.lookForGoVersion(context.Background())
+	if len(s.digest) != 0 {
+		t.Errorf("Silenced alert was raised: %v", s.digest)
+	}
+}
+
+func TestAddSilenceMatchesNothing(t *testing.T) {
+	s := InitTestServer()
+
+	_, err := s.AddSilence(context.Background(), &pb.AddSilenceRequest{Silence: &pb.Silence{End: time.Now().Add(time.Hour).Unix(), Author: "brotherlogic"}})
+	if err == nil {
+		t.Errorf("Added a silence that silences everything")
+	}
+}
+
+func TestAddSilenceNoAuthor(t *testing.T) {
+	s := InitTestServer()
+
+	_, err := s.AddSilence(context.Background(), &pb.AddSilenceRequest{Silence: &pb.Silence{Service: "gobuildslave", End: time.Now().Add(time.Hour).Unix()}})
+	if err == nil {
+		t.Errorf("Added a silence without an author")
+	}
+}
+
+func TestDeleteSilence(t *testing.T) {
+	s := InitTestServer()
+
+	silence, err := s.AddSilence(context.Background(), &pb.AddSilenceRequest{Silence: &pb.Silence{Service: "gobuildslave", End: time.Now().Add(time.Hour).Unix(), Author: "brotherlogic"}})
+	if err != nil {
+		t.Fatalf("Unable to add silence: %v", err)
+	}
+
+	_, err = s.DeleteSilence(context.Background(), &pb.DeleteSilenceRequest{Id: silence.GetSilence().GetId()})
+	if err != nil {
+		t.Fatalf("Unable to delete silence: %v", err)
+	}
+
+	silences, err := s.ListSilences(context.Background(), &pb.ListSilencesRequest{})
+	if err != nil || len(silences.GetSilences()) != 0 {
+		t.Errorf("Silence was not deleted: %v, %v", silences, err)
+	}
+
+	_, err = s.DeleteSilence(context.Background(), &pb.DeleteSilenceRequest{Id: silence.GetSilence().GetId()})
+	if err == nil {
+		t.Errorf("Deleted a missing silence")
+	}
+}
+
+func TestAddSilenceKeepsStoredSilences(t *testing.T) {
+	s := InitTestServer()
+	other := InitTestServer()
+	other.GoServer.KSclient = s.GoServer.KSclient
+
+	_, err := other.AddSilence(context.Background(), &pb.AddSilenceRequest{Silence: &pb.Silence{Service: "recordcollection", End: time.Now().Add(time.Hour).Unix(), Author: "brotherlogic"}})
+	if err != nil {
+		t.Fatalf("Unable to add silence: %v", err)
+	}
+	_, err = s.AddSilence(context.Background(), &pb.AddSilenceRequest{Silence: &pb.Silence{Service: "gobuildslave", End: time.Now().Add(time.Hour).Unix(), Author: "brotherlogic"}})
+	if err != nil {
+		t.Fatalf("Unable to add silence: %v", err)
+	}
+
+	other.loadSilences(context.Background())
+	if len(other.silences.GetSilences()) != 2 {
+		t.Errorf("Silence added elsewhere was lost: %v", other.silences)
+	}
+}
+
+func TestRefreshSilencesStops(t *testing.T) {
+	s := InitTestServer()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan bool)
+	go func() {
+		s.refreshSilences(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Errorf("Silence refresh did not stop")
+	}
+}
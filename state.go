@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+const (
+	// STATE is where we store the alerter state in keystore
+	STATE = "github.com/brotherlogic/alerter/state"
+)
+
+// mismatchSince records a version mismatch for the key, returning when it began
+func (s *Server) mismatchSince(key string) time.Time {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	if _, ok := s.lastMismatchTime[key]; !ok {
+		s.lastMismatchTime[key] = time.Now()
+	}
+	return s.lastMismatchTime[key]
+}
+
+func (s *Server) clearMismatch(key string) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	delete(s.lastMismatchTime, key)
+}
+
+// breachSince records a rule breach for the key, returning when it began
+func (s *Server) breachSince(key string) time.Time {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	if _, ok := s.ruleBreaches[key]; !ok {
+		s.ruleBreaches[key] = time.Now()
+	}
+	return s.ruleBreaches[key]
+}
+
+func (s *Server) clearBreach(key string) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	delete(s.ruleBreaches, key)
+}
+
+// pruneTimes drops the times of every key that was not seen
+func pruneTimes(times map[string]time.Time, seen map[string]bool) {
+	for key := range times {
+		if !seen[key] {
+			delete(times, key)
+		}
+	}
+}
+
+// pruneMismatches forgets the mismatches of jobs that were not seen in this run
+func (s *Server) pruneMismatches(seen map[string]bool) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	pruneTimes(s.lastMismatchTime, seen)
+}
+
+// pruneBreaches forgets the breaches of rules that were not evaluated in this run
+func (s *Server) pruneBreaches(seen map[string]bool) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	pruneTimes(s.ruleBreaches, seen)
+}
+
+// addBuildSample adds to the rolling window of concurrent build samples, returning the window
+func (s *Server) addBuildSample(sample int64, window int) []int64 {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	s.buildSamples = append(s.buildSamples, sample)
+	if len(s.buildSamples) > window {
+		s.buildSamples = s.buildSamples[len(s.buildSamples)-window:]
+	}
+	return append([]int64{}, s.buildSamples...)
+}
+
+func toUnix(times map[string]time.Time) map[string]int64 {
+	converted := make(map[string]int64)
+	for key, t := range times {
+		converted[key] = t.Unix()
+	}
+	return converted
+}
+
+func fromUnix(times map[string]int64) map[string]time.Time {
+	converted := make(map[string]time.Time)
+	for key, t := range times {
+		converted[key] = time.Unix(t, 0)
+	}
+	return converted
+}
+
+func copyCounts(counts map[string]int32) map[string]int32 {
+	copied := make(map[string]int32)
+	for key, count := range counts {
+		copied[key] = count
+	}
+	return copied
+}
+
+func copyStarts(starts map[string]int64) map[string]int64 {
+	copied := make(map[string]int64)
+	for key, start := range starts {
+		copied[key] = start
+	}
+	return copied
+}
+
+func toRestarts(restarts map[string][]int64) map[string]*pb.Restarts {
+	converted := make(map[string]*pb.Restarts)
+	for key, times := range restarts {
+		converted[key] = &pb.Restarts{Times: append([]int64{}, times...)}
+	}
+	return converted
+}
+
+func fromRestarts(restarts map[string]*pb.Restarts) map[string][]int64 {
+	converted := make(map[string][]int64)
+	for key, times := range restarts {
+		converted[key] = append([]int64{}, times.GetTimes()...)
+	}
+	return converted
+}
+
+func toList(set map[string]bool) []string {
+	list := []string{}
+	for key := range set {
+		list = append(list, key)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func fromList(list []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, key := range list {
+		set[key] = true
+	}
+	return set
+}
+
+// saveState writes the alerts, timers and check history to keystore
+func (s *Server) saveState(ctx context.Context) error {
+	s.stateMutex.Lock()
+	state := &pb.AlerterState{
+		Alerts:           s.alerts.snapshot(),
+		LastMismatchTime: toUnix(s.lastMismatchTime),
+		HighCpu:          toUnix(s.highCPU),
+		RuleBreaches:     toUnix(s.ruleBreaches),
+		BuildSamples:     append([]int64{}, s.buildSamples...),
+		ServiceCounts:    copyCounts(s.serviceCounts),
+		JobStarts:        copyStarts(s.jobStarts),
+		JobRestarts:      toRestarts(s.jobRestarts),
+		JobStuck:         toUnix(s.jobStuck),
+		MasterProblems:   toUnix(s.masterProblems),
+		Mastered:         toList(s.mastered),
+	}
+	s.stateMutex.Unlock()
+
+	return s.KSclient.Save(ctx, STATE, state)
+}
+
+// loadState reads back the state saved by whichever alerter was last master
+func (s *Server) loadState(ctx context.Context) error {
+	data, _, err := s.KSclient.Read(ctx, STATE, &pb.AlerterState{})
+	if err != nil {
+		// Nothing has been saved yet, so we start afresh
+		if code := status.Convert(err).Code(); code == codes.NotFound || code == codes.InvalidArgument {
+			s.Log(fmt.Sprintf("No alerter state to load: %v", err))
+			return nil
+		}
+		return err
+	}
+	state := data.(*pb.AlerterState)
+
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	s.alerts.restore(state.GetAlerts())
+	s.lastMismatchTime = fromUnix(state.GetLastMismatchTime())
+	s.highCPU = fromUnix(state.GetHighCpu())
+	s.ruleBreaches = fromUnix(state.GetRuleBreaches())
+	s.buildSamples = state.GetBuildSamples()
+	s.serviceCounts = copyCounts(state.GetServiceCounts())
+	s.jobStarts = copyStarts(state.GetJobStarts())
+	s.jobRestarts = fromRestarts(state.GetJobRestarts())
+	s.jobStuck = fromUnix(state.GetJobStuck())
+	s.masterProblems = fromUnix(state.GetMasterProblems())
+	s.mastered = fromList(state.GetMastered())
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+func TestSaveAndLoadState(t *testing.T) {
+	s := InitTestServer()
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runVersionCheck(context.Background(), time.Minute*20)
+
+	err := s.saveState(context.Background())
+	if err != nil {
+		t.Fatalf("Unable to save state: %v", err)
+	}
+
+	s.alerts.restore(nil)
+	s.lastMismatchTime = make(map[string]time.Time)
+
+	err = s.Mote(context.Background(), true)
+	if err != nil {
+		t.Fatalf("Unable to load state: %v", err)
+	}
+
+	if _, ok := s.alerts.get("stale_version:madeup"); !ok {
+		t.Errorf("Alert was not reloaded")
+	}
+	if time.Since(s.lastMismatchTime["madeup"]) < time.Hour {
+		t.Errorf("Mismatch time was not reloaded: %v", s.lastMismatchTime)
+	}
+}
+
+func TestRunTaskSavesState(t *testing.T) {
+	s := InitTestServer()
+	s.Mote(context.Background(), true)
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+
+	_, err := s.runTask(context.Background(), "run_version_check")
+	if err != nil {
+		t.Fatalf("Unable to run task: %v", err)
+	}
+
+	s.lastMismatchTime = make(map[string]time.Time)
+	s.loadState(context.Background())
+	if _, ok := s.lastMismatchTime["madeup"]; !ok {
+		t.Errorf("State was not saved after the task: %v", s.lastMismatchTime)
+	}
+}
+
+func TestNonMasterSkipsTasks(t *testing.T) {
+	s := InitTestServer()
+	s.Mote(context.Background(), true)
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runTask(context.Background(), "run_version_check")
+
+	// Another alerter shares the keystore and wins the lock for the same task
+	other := InitTestServer()
+	other.GoServer.KSclient = s.GoServer.KSclient
+	other.Registry = &pbd.RegistryEntry{Identifier: "other", Port: 50051}
+	other.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	_, err := other.runTask(context.Background(), "run_version_check")
+	if status.Convert(err).Code() != codes.FailedPrecondition {
+		t.Errorf("Task ran away from the master: %v", err)
+	}
+	if alerts := other.alerts.list(true); len(alerts) != 0 {
+		t.Errorf("Non master raised alerts: %v", alerts)
+	}
+
+	s.alerts.restore(nil)
+	s.loadState(context.Background())
+	if alert, ok := s.alerts.get("stale_version:madeup"); !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Errorf("Master's saved alert was lost: %v", alert)
+	}
+}
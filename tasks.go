@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbg "github.com/brotherlogic/goserver/proto"
+)
+
+const (
+	// How long we wait for running tasks when shutting down
+	shutdownDeadline = time.Second * 30
+
+	// How long we wait for cancelled tasks to return when shutting down
+	cancelDeadline = time.Second * 10
+)
+
+// taskKey marks the name of the running task on its context
+type taskKey struct{}
+
+// taskStatus records how the runs of a task have gone
+type taskStatus struct {
+	firstRun     time.Time
+	lastRun      time.Time
+	lastSuccess  time.Time
+	lastDuration time.Duration
+	lastError    error
+
+	// How long the task asked to wait before its next run
+	interval time.Duration
+
+	runs     int64
+	failures int64
+
+	// The number of services and jobs the last run examined
+	services int
+	jobs     int
+}
+
+// recordExamined adds to the count of services and jobs examined by the running task
+func (s *Server) recordExamined(ctx context.Context, services, jobs int) {
+	name, ok := ctx.Value(taskKey{}).(string)
+	if !ok {
+		return
+	}
+
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+	if run, ok := s.taskStatus[name]; ok {
+		run.services += services
+		run.jobs += jobs
+	}
+}
+
+func (s *Server) startRun(name string) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+	run, ok := s.taskStatus[name]
+	if !ok {
+		run = &taskStatus{firstRun: time.Now()}
+		s.taskStatus[name] = run
+	}
+	run.lastRun = time.Now()
+	run.services = 0
+	run.jobs = 0
+}
+
+func (s *Server) finishRun(name string, next time.Time, err error) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+	run := s.taskStatus[name]
+	run.lastDuration = time.Since(run.lastRun)
+	run.lastError = err
+	run.interval = next.Sub(run.lastRun)
+	run.runs++
+	if err != nil {
+		run.failures++
+	} else {
+		run.lastSuccess = time.Now()
+	}
+}
+
+// trackTask records the cancel function for a running task, returning an id to untrack it with
+func (s *Server) trackTask(cancel context.CancelFunc) int64 {
+	s.runningMutex.Lock()
+	defer s.runningMutex.Unlock()
+	s.runCount++
+	s.running[s.runCount] = cancel
+	return s.runCount
+}
+
+func (s *Server) untrackTask(id int64) {
+	s.runningMutex.Lock()
+	defer s.runningMutex.Unlock()
+	delete(s.running, id)
+}
+
+// cancelTasks cancels every running task
+func (s *Server) cancelTasks() {
+	s.runningMutex.Lock()
+	defer s.runningMutex.Unlock()
+	for _, cancel := range s.running {
+		cancel()
+	}
+}
+
+// runTask runs the named task, saving our state once it completes. Only the master holds
+// the alert state, so the task is skipped on any other alerter that wins its lock. Tasks
+// do not start while we are changing mastership.
+func (s *Server) runTask(ctx context.Context, name string) (time.Time, error) {
+	task, ok := s.tasks[name]
+	if !ok {
+		return time.Now().Add(time.Minute * 5), status.Errorf(codes.NotFound, "Unable to locate task %v", name)
+	}
+
+	s.taskLock.RLock()
+	defer s.taskLock.RUnlock()
+
+	if !s.isMaster() {
+		s.Log(fmt.Sprintf("Skipping %v since we are not master", name))
+		return time.Now().Add(time.Minute * 5), status.Errorf(codes.FailedPrecondition, "Unable to run %v away from the master", name)
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, taskKey{}, name))
+	defer cancel()
+	defer s.untrackTask(s.trackTask(cancel))
+
+	s.startRun(name)
+	next, err := task(ctx)
+	s.finishRun(name, next, err)
+
+	if herr := s.saveHeartbeat(ctx, name); herr != nil {
+		s.Log(fmt.Sprintf("Unable to save heartbeat for %v: %v", name, herr))
+	}
+
+	if serr := s.saveState(ctx); serr != nil {
+		s.Log(fmt.Sprintf("Unable to save state after %v: %v", name, serr))
+	}
+
+	return next, err
+}
+
+// promote loads our state and reconciles the open alerts before any task runs
+func (s *Server) promote(ctx context.Context) error {
+	s.taskLock.Lock()
+	defer s.taskLock.Unlock()
+
+	err := s.loadState(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.setMaster(true)
+
+	// Anything the checks have not raised recently was left open by an earlier master
+	s.alerts.resolveStale(ctx, func(alert *pb.Alert) time.Duration {
+		return s.staleAge(ctx, alert)
+	})
+	return nil
+}
+
+// staleAge is how long an alert can go without being raised before we treat it as left
+// behind, allowing for how often the task that raised it runs
+func (s *Server) staleAge(ctx context.Context, alert *pb.Alert) time.Duration {
+	age := time.Duration(s.getConfig().GetStaleAlertAge()) * time.Second
+	if len(alert.GetTask()) == 0 {
+		return age
+	}
+
+	heartbeat, err := s.readHeartbeat(ctx, alert.GetTask())
+	if err != nil || heartbeat == nil {
+		return age
+	}
+
+	interval := time.Duration(heartbeat.GetInterval()) * time.Second * time.Duration(s.getConfig().GetHealthMultiple())
+	if interval > age {
+		return interval
+	}
+	return age
+}
+
+// demote stops the running tasks and, if we were master, flushes our state for the next one
+func (s *Server) demote(ctx context.Context) error {
+	s.cancelTasks()
+
+	s.taskLock.Lock()
+	defer s.taskLock.Unlock()
+	if !s.isMaster() {
+		return nil
+	}
+
+	s.setMaster(false)
+	return s.saveState(ctx)
+}
+
+func (s *Server) isMaster() bool {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	return s.master
+}
+
+func (s *Server) setMaster(master bool) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	s.master = master
+}
+
+// lockingTask adapts the named task for RegisterLockingTask
+func (s *Server) lockingTask(name string) func(ctx context.Context) (time.Time, error) {
+	return func(ctx context.Context) (time.Time, error) {
+		return s.runTask(ctx, name)
+	}
+}
+
+// taskStates reports the last run of every task
+func (s *Server) taskStates() []*pbg.State {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	names := []string{}
+	for name := range s.taskStatus {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	states := []*pbg.State{}
+	for _, name := range names {
+		run := s.taskStatus[name]
+		lastError := ""
+		if run.lastError != nil {
+			lastError = run.lastError.Error()
+		}
+		states = append(states,
+			&pbg.State{Key: name + "_last_run", TimeValue: run.lastRun.Unix()},
+			&pbg.State{Key: name + "_last_duration", TimeDuration: run.lastDuration.Nanoseconds()},
+			&pbg.State{Key: name + "_last_error", Text: lastError},
+			&pbg.State{Key: name + "_services", Value: int64(run.services)},
+			&pbg.State{Key: name + "_jobs", Value: int64(run.jobs)},
+			&pbg.State{Key: name + "_runs", Value: run.runs},
+			&pbg.State{Key: name + "_failures", Value: run.failures},
+		)
+	}
+	return states
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbg "github.com/brotherlogic/goserver/proto"
+)
+
+// blockingTask runs until it is cancelled, noting that it was
+func blockingTask(started, cancelled chan bool) func(ctx context.Context) (time.Time, error) {
+	return func(ctx context.Context) (time.Time, error) {
+		started <- true
+		<-ctx.Done()
+		cancelled <- true
+		return time.Now(), ctx.Err()
+	}
+}
+
+func TestDemotionStopsTasks(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	started := make(chan bool, 1)
+	cancelled := make(chan bool, 1)
+	s.tasks["blocker"] = blockingTask(started, cancelled)
+
+	go s.runTask(context.Background(), "blocker")
+	<-started
+
+	err := s.Mote(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Unable to demote: %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	default:
+		t.Errorf("Task was still running after demotion")
+	}
+}
+
+func TestPromotionResolvesStaleAlerts(t *testing.T) {
+	s := InitTestServer()
+	s.alerts.restore([]*pb.Alert{
+		&pb.Alert{Key: "old", Check: "old", State: pb.Alert_FIRING, LastRaised: time.Now().Add(-time.Hour * 2).Unix()},
+		&pb.Alert{Key: "new", Check: "new", State: pb.Alert_FIRING, LastRaised: time.Now().Unix()},
+	})
+	s.saveState(context.Background())
+
+	err := s.Mote(context.Background(), true)
+	if err != nil {
+		t.Fatalf("Unable to promote: %v", err)
+	}
+
+	if alert, _ := s.alerts.get("old"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Stale alert was not resolved: %v", alert)
+	}
+	if alert, _ := s.alerts.get("new"); alert.GetState() != pb.Alert_FIRING {
+		t.Errorf("Fresh alert was resolved: %v", alert)
+	}
+}
+
+func TestShutdownWaitsForTasks(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	started := make(chan bool, 1)
+	cancelled := make(chan bool, 1)
+	s.tasks["blocker"] = blockingTask(started, cancelled)
+
+	go s.runTask(context.Background(), "blocker")
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	s.Shutdown(ctx)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second * 5):
+		t.Errorf("Task was not cancelled after the shutdown deadline")
+	}
+}
+
+func findState(states []*pbg.State, key string) *pbg.State {
+	for _, state := range states {
+		if state.Key == key {
+			return state
+		}
+	}
+	return nil
+}
+
+func TestGetStateReportsRuns(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runTask(context.Background(), "run_version_check")
+	s.runTask(context.Background(), "evaluate_friends")
+
+	states := s.GetState()
+	if state := findState(states, "run_version_check_jobs"); state.GetValue() != 1 {
+		t.Errorf("Bad job count: %v", state)
+	}
+	if state := findState(states, "run_version_check_services"); state.GetValue() != 1 {
+		t.Errorf("Bad service count: %v", state)
+	}
+	if state := findState(states, "run_version_check_last_run"); state.GetTimeValue() == 0 {
+		t.Errorf("Bad last run: %v", state)
+	}
+	if state := findState(states, "raised_stale_version"); state.GetValue() != 1 {
+		t.Errorf("Bad raised count: %v", state)
+	}
+	if state := findState(states, "open_alerts"); state.GetValue() != 1 {
+		t.Errorf("Bad open alert count: %v", state)
+	}
+	if state := findState(states, "alert_count"); state.GetValue() != 1 {
+		t.Errorf("Bad alert count: %v", state)
+	}
+}
+
+func TestGetStateReportsErrors(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.discover = &testDiscovery{failget: true}
+	s.runTask(context.Background(), "evaluate_friends")
+
+	if state := findState(s.GetState(), "evaluate_friends_last_error"); len(state.GetText()) == 0 {
+		t.Errorf("Error was not reported: %v", state)
+	}
+}
+
+func TestShutdownSavesAfterTasks(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	started := make(chan bool, 1)
+	s.tasks["writer"] = func(ctx context.Context) (time.Time, error) {
+		started <- true
+		<-ctx.Done()
+		time.Sleep(time.Millisecond * 100)
+		s.mismatchSince("late")
+		return time.Now(), ctx.Err()
+	}
+
+	go s.runTask(context.Background(), "writer")
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	s.Shutdown(ctx)
+
+	s.lastMismatchTime = make(map[string]time.Time)
+	s.loadState(context.Background())
+	if _, ok := s.lastMismatchTime["late"]; !ok {
+		t.Errorf("Shutdown saved before the cancelled task finished: %v", s.lastMismatchTime)
+	}
+}
+
+func TestPromotionKeepsSlowCheckAlerts(t *testing.T) {
+	s := InitTestServer()
+	s.KSclient.Save(context.Background(), HEARTBEATS+"run_version_check", &pb.Heartbeat{Task: "run_version_check", Interval: 60 * 60})
+	s.alerts.restore([]*pb.Alert{
+		&pb.Alert{Key: "stale_version:madeup", Check: "stale_version", Subject: "madeup", Task: "run_version_check", State: pb.Alert_FIRING, LastRaised: time.Now().Add(-time.Hour * 2).Unix()},
+	})
+	s.saveState(context.Background())
+
+	err := s.Mote(context.Background(), true)
+	if err != nil {
+		t.Fatalf("Unable to promote: %v", err)
+	}
+
+	if alert, _ := s.alerts.get("stale_version:madeup"); alert.GetState() != pb.Alert_FIRING {
+		t.Errorf("Alert from an hourly check was resolved: %v", alert)
+	}
+}
+
+func TestFireRecordsTask(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.lastMismatchTime["madeup"] = time.Now().Add(-time.Hour)
+	s.runTask(context.Background(), "run_version_check")
+
+	if alert, _ := s.alerts.get("stale_version:madeup"); alert.GetTask() != "run_version_check" {
+		t.Errorf("Task was not recorded: %v", alert)
+	}
+}
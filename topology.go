@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// buildFriendGraph walks the discovery friends from every seed, asking each node we find for
+// its friends. The seeds are nodes in their own right, so we can see partitions between them.
+func (s *Server) buildFriendGraph(ctx context.Context) (*pb.FriendGraph, error) {
+	seeds := s.discoverySeeds()
+	nodes := make(map[string]*pb.FriendNode)
+	queue := append([]string{}, seeds...)
+	for len(queue) > 0 {
+		address := queue[0]
+		queue = queue[1:]
+		if _, ok := nodes[address]; ok {
+			continue
+		}
+
+		node := &pb.FriendNode{Address: address}
+		nodes[address] = node
+		rfriends, err := s.discover.getRemoteFriends(ctx, address)
+		if err != nil {
+			node.Unreachable = true
+			continue
+		}
+		for _, friend := range parseFriends(rfriends) {
+			if friend != address {
+				node.Friends = append(node.Friends, friend)
+				queue = append(queue, friend)
+			}
+		}
+		sort.Strings(node.Friends)
+	}
+
+	reached := false
+	for _, seed := range seeds {
+		reached = reached || !nodes[seed].Unreachable
+	}
+	if !reached {
+		return nil, status.Errorf(codes.Unavailable, "Unable to reach any of %v", seeds)
+	}
+
+	graph := &pb.FriendGraph{Seeds: seeds}
+	addresses := []string{}
+	for address := range nodes {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		graph.Nodes = append(graph.Nodes, nodes[address])
+	}
+
+	labelComponents(graph)
+	return graph, nil
+}
+
+// labelComponents numbers the connected components of the graph, ignoring the direction of each link
+func labelComponents(graph *pb.FriendGraph) {
+	links := make(map[string][]string)
+	for _, node := range graph.Nodes {
+		for _, friend := range node.Friends {
+			links[node.Address] = append(links[node.Address], friend)
+			links[friend] = append(links[friend], node.Address)
+		}
+	}
+
+	component := make(map[string]int32)
+	count := int32(0)
+	for _, node := range graph.Nodes {
+		if _, ok := component[node.Address]; ok {
+			continue
+		}
+
+		queue := []string{node.Address}
+		component[node.Address] = count
+		for len(queue) > 0 {
+			address := queue[0]
+			queue = queue[1:]
+			for _, linked := range links[address] {
+				if _, ok := component[linked]; !ok {
+					component[linked] = count
+					queue = append(queue, linked)
+				}
+			}
+		}
+		count++
+	}
+
+	for _, node := range graph.Nodes {
+		node.Component = component[node.Address]
+	}
+}
+
+// asymmetricLinks finds the links that are only held in one direction, skipping nodes we couldn't reach
+func asymmetricLinks(graph *pb.FriendGraph) []string {
+	nodes := make(map[string]*pb.FriendNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Address] = node
+	}
+
+	links := []string{}
+	for _, node := range graph.Nodes {
+		for _, friend := range node.Friends {
+			other, ok := nodes[friend]
+			if !ok || other.Unreachable {
+				continue
+			}
+
+			found := false
+			for _, back := range other.Friends {
+				if back == node.Address {
+					found = true
+				}
+			}
+			if !found {
+				links = append(links, node.Address+"->"+friend)
+			}
+		}
+	}
+	return links
+}
+
+// isolatedNodes finds the nodes we couldn't reach that no other node lists as a friend. We
+// know nothing about where they sit in the graph, so they don't count towards a partition.
+func isolatedNodes(graph *pb.FriendGraph) map[string]bool {
+	listed := make(map[string]bool)
+	for _, node := range graph.Nodes {
+		for _, friend := range node.Friends {
+			listed[friend] = true
+		}
+	}
+
+	isolated := make(map[string]bool)
+	for _, node := range graph.Nodes {
+		if node.Unreachable && len(node.Friends) == 0 && !listed[node.Address] {
+			isolated[node.Address] = true
+		}
+	}
+	return isolated
+}
+
+// components groups the node addresses by the component they belong to, leaving out the
+// isolated nodes
+func components(graph *pb.FriendGraph) [][]string {
+	isolated := isolatedNodes(graph)
+	grouped := [][]string{}
+	for _, node := range graph.Nodes {
+		for int(node.Component) >= len(grouped) {
+			grouped = append(grouped, []string{})
+		}
+		if !isolated[node.Address] {
+			grouped[node.Component] = append(grouped[node.Component], node.Address)
+		}
+	}
+
+	found := [][]string{}
+	for _, group := range grouped {
+		if len(group) > 0 {
+			found = append(found, group)
+		}
+	}
+	return found
+}
+
+// renderDot draws the graph for graphviz, marking the nodes we couldn't reach
+func renderDot(graph *pb.FriendGraph) string {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("digraph friends {\n")
+	for _, node := range graph.Nodes {
+		if node.Unreachable {
+			buffer.WriteString(fmt.Sprintf("  %q [style=dashed];\n", node.Address))
+		} else {
+			buffer.WriteString(fmt.Sprintf("  %q;\n", node.Address))
+		}
+	}
+	for _, node := range graph.Nodes {
+		for _, friend := range node.Friends {
+			buffer.WriteString(fmt.Sprintf("  %q -> %q;\n", node.Address, friend))
+		}
+	}
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
+func renderGraph(graph *pb.FriendGraph, format pb.GetFriendGraphRequest_Format) (string, error) {
+	if format == pb.GetFriendGraphRequest_DOT {
+		return renderDot(graph), nil
+	}
+	marshaler := &jsonpb.Marshaler{}
+	return marshaler.MarshalToString(graph)
+}
+
+// mapFriends builds the friend graph, alerting on one way friendships, partitions and nodes
+// that nothing can reach
+func (s *Server) mapFriends(ctx context.Context) (time.Time, error) {
+	graph, err := s.buildFriendGraph(ctx)
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+	s.recordExamined(ctx, len(graph.Nodes), 0)
+
+	seen := make(map[string]bool)
+	for _, link := range asymmetricLinks(graph) {
+		seen[link] = true
+		s.alerts.fire(ctx, &pb.Alert{Check: "friend_topology", Subject: link, Labels: map[string]string{"service": "discovery", "link": link}, Severity: pb.Severity_WARNING, Title: "Asymmetric Friends",
+			Body: fmt.Sprintf("%v is only held in one direction (via %v)", strings.Replace(link, "->", " lists ", 1), strings.Join(graph.Seeds, ", "))})
+	}
+
+	for address := range isolatedNodes(graph) {
+		seen["unreachable:"+address] = true
+		s.alerts.fire(ctx, &pb.Alert{Check: "friend_topology", Subject: "unreachable:" + address, Labels: map[string]string{"service": "discovery", "identifier": address}, Severity: pb.Severity_WARNING, Title: "Discovery Unreachable",
+			Body: fmt.Sprintf("%v is not answering and no other discovery server lists it (via %v)", address, strings.Join(graph.Seeds, ", "))})
+	}
+
+	if grouped := components(graph); len(grouped) > 1 {
+		seen["partition"] = true
+		parts := []string{}
+		for _, group := range grouped {
+			parts = append(parts, "["+strings.Join(group, " ")+"]")
+		}
+		s.alerts.fire(ctx, &pb.Alert{Check: "friend_topology", Subject: "partition", Labels: map[string]string{"service": "discovery"}, Severity: pb.Severity_CRITICAL, Title: "Discovery Partition",
+			Body: fmt.Sprintf("Discovery is split into %v components: %v (via %v)", len(grouped), strings.Join(parts, " "), strings.Join(graph.Seeds, ", "))})
+	}
+	s.alerts.passUnseen(ctx, "friend_topology", seen)
+
+	return time.Now().Add(time.Minute * 5), nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+func TestAsymmetricFriends(t *testing.T) {
+	s := InitTestServer()
+	s.seeds = []string{"a"}
+	s.discover = &testDiscovery{remote: map[string]string{"a": "[b]", "b": "[]"}}
+
+	s.mapFriends(context.Background())
+
+	alert, ok := s.alerts.get("friend_topology:a->b")
+	if !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Errorf("Asymmetric link was not raised: %v", s.alerts.list(true))
+	}
+	if alert, ok := s.alerts.get("friend_topology:partition"); ok {
+		t.Errorf("Connected graph was reported as partitioned: %v", alert)
+	}
+}
+
+func TestSeedJoinsGraph(t *testing.T) {
+	s := InitTestServer()
+	s.seeds = []string{"seed"}
+	s.discover = &testDiscovery{remote: map[string]string{"seed": "a c", "a": "seed", "c": "seed"}}
+
+	s.mapFriends(context.Background())
+
+	if alert, ok := s.alerts.get("friend_topology:partition"); ok {
+		t.Errorf("Graph joined through the seed was reported as partitioned: %v", alert)
+	}
+}
+
+func TestDiscoveryPartition(t *testing.T) {
+	s := InitTestServer()
+	s.seeds = []string{"a", "c"}
+	s.discover = &testDiscovery{remote: map[string]string{"a": "b", "b": "a", "c": "d", "d": "c"}}
+
+	s.mapFriends(context.Background())
+
+	alert, ok := s.alerts.get("friend_topology:partition")
+	if !ok || !strings.Contains(alert.GetBody(), "[a b] [c d]") {
+		t.Errorf("Partition was not raised: %v", alert)
+	}
+}
+
+func TestUnreachableSeedIsNotPartition(t *testing.T) {
+	s := InitTestServer()
+	s.seeds = []string{"a", "down"}
+	s.discover = &testDiscovery{remote: map[string]string{"a": "b", "b": "a"}}
+
+	s.mapFriends(context.Background())
+
+	if alert, ok := s.alerts.get("friend_topology:partition"); ok {
+		t.Errorf("Unreachable seed was reported as a partition: %v", alert)
+	}
+	alert, ok := s.alerts.get("friend_topology:unreachable:down")
+	if !ok || alert.GetState() != pb.Alert_FIRING || alert.GetSeverity() != pb.Severity_WARNING {
+		t.Errorf("Unreachable seed was not raised: %v", s.alerts.list(true))
+	}
+
+	s.discover = &testDiscovery{remote: map[string]string{"a": "b down", "b": "a down", "down": "a b"}}
+	s.mapFriends(context.Background())
+
+	if alert, _ := s.alerts.get("friend_topology:unreachable:down"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Unreachable seed was not resolved: %v", alert)
+	}
+}
+
+func TestUnreachableFriendIsNotAsymmetric(t *testing.T) {
+	s := InitTestServer()
+	s.seeds = []string{"a"}
+	s.discover = &testDiscovery{remote: map[string]string{"a": "b"}}
+
+	s.mapFriends(context.Background())
+
+	if alert, ok := s.alerts.get("friend_topology:a->b"); ok {
+		t.Errorf("Link to an unreachable node was raised: %v", alert)
+	}
+}
+
+func TestNoSeedReachable(t *testing.T) {
+	s := InitTestServer()
+	s.seeds = []string{"a"}
+	s.discover = &testDiscovery{remote: map[string]string{}}
+
+	_, err := s.mapFriends(context.Background())
+	if err == nil {
+		t.Errorf("Mapped friends without reaching a seed")
+	}
+}
+
+func TestGetFriendGraph(t *testing.T) {
+	s := InitTestServer()
+	s.seeds = []string{"a"}
+	s.discover = &testDiscovery{remote: map[string]string{"a": "b", "b": "a"}}
+
+	resp, err := s.GetFriendGraph(context.Background(), &pb.GetFriendGraphRequest{Format: pb.GetFriendGraphRequest_DOT})
+	if err != nil {
+		t.Fatalf("Unable to get graph: %v", err)
+	}
+	if len(resp.GetGraph().GetNodes()) != 2 || !strings.Contains(resp.GetRendered(), "\"a\" -> \"b\";") {
+		t.Errorf("Bad graph: %v", resp)
+	}
+
+	resp, err = s.GetFriendGraph(context.Background(), &pb.GetFriendGraphRequest{Format: pb.GetFriendGraphRequest_JSON})
+	if err != nil || !strings.Contains(resp.GetRendered(), "\"address\":\"a\"") {
+		t.Errorf("Bad JSON graph: %v, %v", resp, err)
+	}
+}
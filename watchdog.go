@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+const (
+	// How often the watchdog checks on the tasks
+	watchdogInterval = time.Minute
+
+	// How long after we start a task can go without ever running
+	watchdogGrace = time.Minute * 15
+)
+
+// stalledTasks checks the shared heartbeat of every task we expect to run, wherever it runs,
+// returning why each stalled task has stalled and the tasks that are running fine. Tasks we
+// can't read a heartbeat for are in neither.
+func (s *Server) stalledTasks(ctx context.Context) (map[string]string, []string) {
+	names := []string{}
+	for name := range s.tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stalled := make(map[string]string)
+	active := []string{}
+	for _, name := range names {
+		heartbeat, err := s.readHeartbeat(ctx, name)
+		if err != nil {
+			s.Log(fmt.Sprintf("Unable to read heartbeat for %v: %v", name, err))
+			continue
+		}
+
+		if heartbeat == nil {
+			if time.Since(s.started) > watchdogGrace {
+				stalled[name] = fmt.Sprintf("%v has never been run", name)
+			}
+			continue
+		}
+
+		interval := time.Duration(heartbeat.GetInterval()) * time.Second
+		if interval < time.Minute {
+			interval = time.Minute
+		}
+
+		lastRun := time.Unix(heartbeat.GetLastRun(), 0)
+		if time.Since(lastRun) > interval*time.Duration(s.getConfig().GetHealthMultiple()) {
+			stalled[name] = fmt.Sprintf("%v has not run since %v, when it ran on %v", name, lastRun.Format(time.RFC822), heartbeat.GetInstance())
+		} else {
+			active = append(active, name)
+		}
+	}
+
+	return stalled, active
+}
+
+// watch raises an alert for every stalled task and sends out our heartbeat. Only the master
+// raises alerts, so the alerters don't each raise their own.
+func (s *Server) watch(ctx context.Context) {
+	if s.isMaster() {
+		stalled, active := s.stalledTasks(ctx)
+		for name, reason := range stalled {
+			s.alerts.fire(ctx, &pb.Alert{Check: "watchdog", Subject: name, Labels: map[string]string{"service": "alerter", "task": name}, Severity: pb.Severity_CRITICAL, Title: "Check Stalled", Body: reason})
+		}
+		for _, name := range active {
+			s.alerts.pass(ctx, "watchdog", name)
+		}
+	}
+
+	if len(s.getConfig().GetHeartbeatUrl()) > 0 {
+		if err := s.heartbeat(ctx, s.getConfig().GetHeartbeatUrl()); err != nil {
+			s.Log(fmt.Sprintf("Unable to send heartbeat: %v", err))
+		}
+	}
+}
+
+// heartbeat tells an external monitor that we are still alive
+func (s *Server) heartbeat(ctx context.Context, url string) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: time.Second * 30}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Heartbeat to %v returned %v", url, resp.Status)
+	}
+	return nil
+}
+
+// watchdog runs outside of the locking tasks, watching over them until the context is done
+func (s *Server) watchdog(ctx context.Context) {
+	ticker := time.NewTicker(watchdogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			wctx, cancel := context.WithTimeout(ctx, watchdogInterval)
+			s.watch(wctx)
+			cancel()
+		}
+	}
+}
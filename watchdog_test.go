@@ -0,0 +1,111 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+)
+
+func TestWatchdogRaisesStall(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.runTask(context.Background(), "check_friends")
+	s.taskStatus["check_friends"].lastRun = time.Now().Add(-time.Hour)
+	s.saveHeartbeat(context.Background(), "check_friends")
+
+	s.watch(context.Background())
+
+	alert, ok := s.alerts.get("watchdog:check_friends")
+	if !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Fatalf("Stall was not raised: %v", alert)
+	}
+
+	s.runTask(context.Background(), "check_friends")
+	s.watch(context.Background())
+
+	if alert, _ = s.alerts.get("watchdog:check_friends"); alert.GetState() != pb.Alert_RESOLVED {
+		t.Errorf("Stall was not resolved: %v", alert)
+	}
+}
+
+func TestWatchdogSeesOtherInstances(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+
+	other := InitTestServer()
+	other.setMaster(true)
+	other.GoServer.KSclient = s.GoServer.KSclient
+	other.runTask(context.Background(), "check_friends")
+	other.taskStatus["check_friends"].lastRun = time.Now().Add(-time.Hour)
+	other.saveHeartbeat(context.Background(), "check_friends")
+
+	s.watch(context.Background())
+
+	if alert, ok := s.alerts.get("watchdog:check_friends"); !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Errorf("Stall on another alerter was not raised: %v", alert)
+	}
+}
+
+func TestWatchdogRaisesNeverRun(t *testing.T) {
+	s := InitTestServer()
+	s.setMaster(true)
+	s.started = time.Now().Add(-time.Hour)
+
+	s.watch(context.Background())
+
+	if alert, ok := s.alerts.get("watchdog:check_friends"); !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Errorf("Task that never ran was not raised: %v", alert)
+	}
+}
+
+func TestWatchdogOnlyOnMaster(t *testing.T) {
+	s := InitTestServer()
+	s.started = time.Now().Add(-time.Hour)
+
+	s.watch(context.Background())
+
+	if alerts := s.alerts.list(true); len(alerts) != 0 {
+		t.Errorf("Non master raised alerts: %v", alerts)
+	}
+}
+
+func TestWatchdogHeartbeat(t *testing.T) {
+	beats := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		beats <- true
+	}))
+	defer server.Close()
+
+	s := InitTestServer()
+	s.config.HeartbeatUrl = server.URL
+	s.watch(context.Background())
+
+	select {
+	case <-beats:
+	default:
+		t.Errorf("No heartbeat was sent")
+	}
+}
+
+func TestWatchdogStops(t *testing.T) {
+	s := InitTestServer()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan bool)
+	go func() {
+		s.watchdog(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Errorf("Watchdog did not stop")
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+)
+
+// zombieReason explains why the entry is not backed by the process that registered it, or
+// returns an empty string if it is
+func (s *Server) zombieReason(ctx context.Context, service *pbd.RegistryEntry) string {
+	stats, err := s.goserver.GetStats(ctx, service.Ip, service.Port)
+	if err != nil {
+		return fmt.Sprintf("not answering (%v)", status.Convert(err).Code())
+	}
+
+	// A process always starts before it registers, so a later start is a different process.
+	// The start time comes from the service's clock and the registration time from
+	// discovery's, so we allow for some skew between them.
+	skew := s.getConfig().GetZombieClockSkew()
+	for _, state := range stats.States {
+		if state.Key == s.getConfig().GetStartTimeKey() && service.RegisterTime > 0 && state.TimeValue > service.RegisterTime+skew {
+			return fmt.Sprintf("answered by a process started at %v", time.Unix(state.TimeValue, 0).Format(time.RFC822))
+		}
+	}
+	return ""
+}
+
+// lookForZombies dials every registered entry, reporting the entries on each host that are
+// no longer backed by the process that registered them
+func (s *Server) lookForZombies(ctx context.Context) (time.Time, error) {
+	serv, err := s.discover.ListAllServices(ctx, &pbd.ListRequest{})
+	if err != nil {
+		return time.Now().Add(time.Minute * 5), err
+	}
+
+	grace := time.Duration(s.getConfig().GetZombieGracePeriod()) * time.Second
+	zombies := make(map[string][]string)
+	for _, service := range serv.GetServices().GetServices() {
+		// Give new registrations a chance to start serving
+		if time.Since(time.Unix(service.RegisterTime, 0)) < grace {
+			continue
+		}
+
+		s.recordExamined(ctx, 1, 0)
+		if reason := s.zombieReason(ctx, service); len(reason) > 0 {
+			zombies[service.Ip] = append(zombies[service.Ip], fmt.Sprintf("%v on %v:%v %v", service.Name, service.Identifier, service.Port, reason))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for host, found := range zombies {
+		seen[host] = true
+		sort.Strings(found)
+		s.alerts.fire(ctx, &pb.Alert{Check: "zombie_registration", Subject: host, Labels: map[string]string{"host": host}, Severity: pb.Severity_WARNING, Title: "Zombie Registrations",
+			Body: fmt.Sprintf("%v has %v zombie registrations: %v", host, len(found), strings.Join(found, "; "))})
+	}
+	s.alerts.passUnseen(ctx, "zombie_registration", seen)
+
+	return time.Now().Add(time.Minute * 5), nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/alerter/proto"
+	pbd "github.com/brotherlogic/discovery/proto"
+	pbg "github.com/brotherlogic/goserver/proto"
+)
+
+func TestZombieRegistrations(t *testing.T) {
+	s := InitTestServer()
+	registered := time.Now().Add(-time.Hour).Unix()
+	s.discover = &testDiscovery{services: []*pbd.RegistryEntry{
+		&pbd.RegistryEntry{Name: "alive", Identifier: "alpha", Ip: "10.0.0.1", Port: 1, RegisterTime: registered},
+		&pbd.RegistryEntry{Name: "gone", Identifier: "alpha", Ip: "10.0.0.1", Port: 2, RegisterTime: registered},
+		&pbd.RegistryEntry{Name: "replaced", Identifier: "alpha", Ip: "10.0.0.1", Port: 3, RegisterTime: registered},
+		&pbd.RegistryEntry{Name: "new", Identifier: "beta", Ip: "10.0.0.2", Port: 1, RegisterTime: time.Now().Unix()},
+	}}
+	s.goserver = &testGoserver{stats: map[string]*pbg.ServerState{
+		"10.0.0.1:1": &pbg.ServerState{States: []*pbg.State{&pbg.State{Key: "startup_time", TimeValue: registered + 10}}},
+		"10.0.0.1:3": &pbg.ServerState{States: []*pbg.State{&pbg.State{Key: "startup_time", TimeValue: registered + 600}}},
+	}}
+
+	s.lookForZombies(context.Background())
+
+	alert, ok := s.alerts.get("zombie_registration:10.0.0.1")
+	if !ok || alert.GetState() != pb.Alert_FIRING {
+		t.Fatalf("Zombies were not raised: %v", alert)
+	}
+	if !strings.Contains(alert.GetBody(), "gone") || !strings.Contains(alert.GetBody(), "replaced") || strings.Contains(alert.GetBody(), "alive") {
+		t.Errorf("Wrong zombies reported: %v", alert.GetBody())
+	}
+	if alert, ok := s.alerts.get("zombie_registration:10.0.0.2"); ok {
+		t.Errorf("New registration was reported: %v", alert)
+	}
+}